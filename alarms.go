@@ -0,0 +1,94 @@
+package main
+
+import (
+	"Q50RT/q50"
+	"sync"
+	"time"
+)
+
+// maxDeviceAlarms is the number of alarms kept per device.
+const maxDeviceAlarms = 100
+
+// Alarm is an AL event. It stays unacknowledged until an API client
+// confirms it was handled.
+type Alarm struct {
+	ID           uint64
+	DeviceID     string
+	Reason       string
+	DeviceTime   time.Time
+	ReceiveTime  time.Time
+	Latitude     float64
+	Longitude    float64
+	Acknowledged bool
+}
+
+// AlarmLog keeps the latest alarms per device.
+type AlarmLog struct {
+	mu      *sync.RWMutex
+	lastID  uint64
+	devices map[string][]*Alarm
+	alarms  map[uint64]*Alarm
+}
+
+func NewAlarmLog() *AlarmLog {
+	return &AlarmLog{
+		mu:      &sync.RWMutex{},
+		devices: make(map[string][]*Alarm),
+		alarms:  make(map[uint64]*Alarm),
+	}
+}
+
+func (l *AlarmLog) Add(message *q50.Message) Alarm {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.lastID++
+	alarm := &Alarm{
+		ID:          l.lastID,
+		DeviceID:    message.ID,
+		Reason:      message.Alarm,
+		DeviceTime:  message.DeviceTime,
+		ReceiveTime: message.ReceiveTime,
+		Latitude:    message.Latitude,
+		Longitude:   message.Longitude,
+	}
+
+	alarms := append(l.devices[message.ID], alarm)
+	if len(alarms) > maxDeviceAlarms {
+		delete(l.alarms, alarms[0].ID)
+		alarms = alarms[1:]
+	}
+	l.devices[message.ID] = alarms
+	l.alarms[alarm.ID] = alarm
+
+	return *alarm
+}
+
+// List returns copies of the device alarms, newest first.
+func (l *AlarmLog) List(deviceID string, unacknowledged bool) []Alarm {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	alarms := l.devices[deviceID]
+	result := make([]Alarm, 0, len(alarms))
+	for i := len(alarms) - 1; i >= 0; i-- {
+		if unacknowledged && alarms[i].Acknowledged {
+			continue
+		}
+		result = append(result, *alarms[i])
+	}
+	return result
+}
+
+func (l *AlarmLog) Acknowledge(id uint64) (Alarm, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	alarm, ok := l.alarms[id]
+	if !ok {
+		return Alarm{}, false
+	}
+
+	alarm.Acknowledged = true
+	return *alarm, true
+}
@@ -70,18 +70,35 @@ func (m *Identifier) GetClientId() string {
 }
 
 type Point struct {
-	Version              string   `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
-	MessageType          string   `protobuf:"bytes,2,opt,name=messageType,proto3" json:"messageType,omitempty"`
-	NetType              string   `protobuf:"bytes,3,opt,name=netType,proto3" json:"netType,omitempty"`
-	DeviceId             string   `protobuf:"bytes,4,opt,name=deviceId,proto3" json:"deviceId,omitempty"`
-	BatteryPercent       uint32   `protobuf:"fixed32,5,opt,name=batteryPercent,proto3" json:"batteryPercent,omitempty"`
-	ReceiveTime          int64    `protobuf:"varint,6,opt,name=receiveTime,proto3" json:"receiveTime,omitempty"`
-	DeviceTime           int64    `protobuf:"varint,7,opt,name=deviceTime,proto3" json:"deviceTime,omitempty"`
-	Latitude             float64  `protobuf:"fixed64,8,opt,name=latitude,proto3" json:"latitude,omitempty"`
-	Longitude            float64  `protobuf:"fixed64,9,opt,name=longitude,proto3" json:"longitude,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+	Version              string       `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	MessageType          string       `protobuf:"bytes,2,opt,name=messageType,proto3" json:"messageType,omitempty"`
+	NetType              string       `protobuf:"bytes,3,opt,name=netType,proto3" json:"netType,omitempty"`
+	DeviceId             string       `protobuf:"bytes,4,opt,name=deviceId,proto3" json:"deviceId,omitempty"`
+	BatteryPercent       uint32       `protobuf:"fixed32,5,opt,name=batteryPercent,proto3" json:"batteryPercent,omitempty"`
+	ReceiveTime          int64        `protobuf:"varint,6,opt,name=receiveTime,proto3" json:"receiveTime,omitempty"`
+	DeviceTime           int64        `protobuf:"varint,7,opt,name=deviceTime,proto3" json:"deviceTime,omitempty"`
+	Latitude             float64      `protobuf:"fixed64,8,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude            float64      `protobuf:"fixed64,9,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	GpsValid             bool         `protobuf:"varint,10,opt,name=gpsValid,proto3" json:"gpsValid,omitempty"`
+	Speed                float64      `protobuf:"fixed64,11,opt,name=speed,proto3" json:"speed,omitempty"`
+	Course               float64      `protobuf:"fixed64,12,opt,name=course,proto3" json:"course,omitempty"`
+	Altitude             float64      `protobuf:"fixed64,13,opt,name=altitude,proto3" json:"altitude,omitempty"`
+	Satellites           uint32       `protobuf:"fixed32,14,opt,name=satellites,proto3" json:"satellites,omitempty"`
+	SignalStrength       uint32       `protobuf:"fixed32,15,opt,name=signalStrength,proto3" json:"signalStrength,omitempty"`
+	Steps                uint32       `protobuf:"fixed32,16,opt,name=steps,proto3" json:"steps,omitempty"`
+	Tumbles              uint32       `protobuf:"fixed32,17,opt,name=tumbles,proto3" json:"tumbles,omitempty"`
+	Historical           bool         `protobuf:"varint,18,opt,name=historical,proto3" json:"historical,omitempty"`
+	Status               *Status      `protobuf:"bytes,19,opt,name=status,proto3" json:"status,omitempty"`
+	CellTowers           []*CellTower `protobuf:"bytes,20,rep,name=cellTowers,proto3" json:"cellTowers,omitempty"`
+	WifiAPs              []*WifiAP    `protobuf:"bytes,21,rep,name=wifiAPs,proto3" json:"wifiAPs,omitempty"`
+	Source               string       `protobuf:"bytes,22,opt,name=source,proto3" json:"source,omitempty"`
+	Accuracy             float64      `protobuf:"fixed64,23,opt,name=accuracy,proto3" json:"accuracy,omitempty"`
+	Alarm                string       `protobuf:"bytes,24,opt,name=alarm,proto3" json:"alarm,omitempty"`
+	ClockSkew            int64        `protobuf:"varint,25,opt,name=clockSkew,proto3" json:"clockSkew,omitempty"`
+	ClockSkewed          bool         `protobuf:"varint,26,opt,name=clockSkewed,proto3" json:"clockSkewed,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
 }
 
 func (m *Point) Reset()         { *m = Point{} }
@@ -172,6 +189,370 @@ func (m *Point) GetLongitude() float64 {
 	return 0
 }
 
+func (m *Point) GetGpsValid() bool {
+	if m != nil {
+		return m.GpsValid
+	}
+	return false
+}
+
+func (m *Point) GetSpeed() float64 {
+	if m != nil {
+		return m.Speed
+	}
+	return 0
+}
+
+func (m *Point) GetCourse() float64 {
+	if m != nil {
+		return m.Course
+	}
+	return 0
+}
+
+func (m *Point) GetAltitude() float64 {
+	if m != nil {
+		return m.Altitude
+	}
+	return 0
+}
+
+func (m *Point) GetSatellites() uint32 {
+	if m != nil {
+		return m.Satellites
+	}
+	return 0
+}
+
+func (m *Point) GetSignalStrength() uint32 {
+	if m != nil {
+		return m.SignalStrength
+	}
+	return 0
+}
+
+func (m *Point) GetSteps() uint32 {
+	if m != nil {
+		return m.Steps
+	}
+	return 0
+}
+
+func (m *Point) GetTumbles() uint32 {
+	if m != nil {
+		return m.Tumbles
+	}
+	return 0
+}
+
+func (m *Point) GetHistorical() bool {
+	if m != nil {
+		return m.Historical
+	}
+	return false
+}
+
+func (m *Point) GetStatus() *Status {
+	if m != nil {
+		return m.Status
+	}
+	return nil
+}
+
+func (m *Point) GetCellTowers() []*CellTower {
+	if m != nil {
+		return m.CellTowers
+	}
+	return nil
+}
+
+func (m *Point) GetWifiAPs() []*WifiAP {
+	if m != nil {
+		return m.WifiAPs
+	}
+	return nil
+}
+
+func (m *Point) GetSource() string {
+	if m != nil {
+		return m.Source
+	}
+	return ""
+}
+
+func (m *Point) GetAccuracy() float64 {
+	if m != nil {
+		return m.Accuracy
+	}
+	return 0
+}
+
+func (m *Point) GetAlarm() string {
+	if m != nil {
+		return m.Alarm
+	}
+	return ""
+}
+
+func (m *Point) GetClockSkew() int64 {
+	if m != nil {
+		return m.ClockSkew
+	}
+	return 0
+}
+
+func (m *Point) GetClockSkewed() bool {
+	if m != nil {
+		return m.ClockSkewed
+	}
+	return false
+}
+
+type CellTower struct {
+	Mcc                  uint32   `protobuf:"fixed32,1,opt,name=mcc,proto3" json:"mcc,omitempty"`
+	Mnc                  uint32   `protobuf:"fixed32,2,opt,name=mnc,proto3" json:"mnc,omitempty"`
+	Lac                  uint32   `protobuf:"fixed32,3,opt,name=lac,proto3" json:"lac,omitempty"`
+	CellId               uint32   `protobuf:"fixed32,4,opt,name=cellId,proto3" json:"cellId,omitempty"`
+	Rssi                 int32    `protobuf:"varint,5,opt,name=rssi,proto3" json:"rssi,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CellTower) Reset()         { *m = CellTower{} }
+func (m *CellTower) String() string { return proto.CompactTextString(m) }
+func (*CellTower) ProtoMessage()    {}
+func (*CellTower) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bc56ba28a6aaff3d, []int{2}
+}
+
+func (m *CellTower) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CellTower.Unmarshal(m, b)
+}
+func (m *CellTower) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CellTower.Marshal(b, m, deterministic)
+}
+func (m *CellTower) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CellTower.Merge(m, src)
+}
+func (m *CellTower) XXX_Size() int {
+	return xxx_messageInfo_CellTower.Size(m)
+}
+func (m *CellTower) XXX_DiscardUnknown() {
+	xxx_messageInfo_CellTower.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CellTower proto.InternalMessageInfo
+
+func (m *CellTower) GetMcc() uint32 {
+	if m != nil {
+		return m.Mcc
+	}
+	return 0
+}
+
+func (m *CellTower) GetMnc() uint32 {
+	if m != nil {
+		return m.Mnc
+	}
+	return 0
+}
+
+func (m *CellTower) GetLac() uint32 {
+	if m != nil {
+		return m.Lac
+	}
+	return 0
+}
+
+func (m *CellTower) GetCellId() uint32 {
+	if m != nil {
+		return m.CellId
+	}
+	return 0
+}
+
+func (m *CellTower) GetRssi() int32 {
+	if m != nil {
+		return m.Rssi
+	}
+	return 0
+}
+
+type WifiAP struct {
+	Ssid                 string   `protobuf:"bytes,1,opt,name=ssid,proto3" json:"ssid,omitempty"`
+	Mac                  string   `protobuf:"bytes,2,opt,name=mac,proto3" json:"mac,omitempty"`
+	Rssi                 int32    `protobuf:"varint,3,opt,name=rssi,proto3" json:"rssi,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *WifiAP) Reset()         { *m = WifiAP{} }
+func (m *WifiAP) String() string { return proto.CompactTextString(m) }
+func (*WifiAP) ProtoMessage()    {}
+func (*WifiAP) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bc56ba28a6aaff3d, []int{3}
+}
+
+func (m *WifiAP) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_WifiAP.Unmarshal(m, b)
+}
+func (m *WifiAP) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_WifiAP.Marshal(b, m, deterministic)
+}
+func (m *WifiAP) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_WifiAP.Merge(m, src)
+}
+func (m *WifiAP) XXX_Size() int {
+	return xxx_messageInfo_WifiAP.Size(m)
+}
+func (m *WifiAP) XXX_DiscardUnknown() {
+	xxx_messageInfo_WifiAP.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_WifiAP proto.InternalMessageInfo
+
+func (m *WifiAP) GetSsid() string {
+	if m != nil {
+		return m.Ssid
+	}
+	return ""
+}
+
+func (m *WifiAP) GetMac() string {
+	if m != nil {
+		return m.Mac
+	}
+	return ""
+}
+
+func (m *WifiAP) GetRssi() int32 {
+	if m != nil {
+		return m.Rssi
+	}
+	return 0
+}
+
+type Status struct {
+	Raw                  uint32   `protobuf:"fixed32,1,opt,name=raw,proto3" json:"raw,omitempty"`
+	LowBattery           bool     `protobuf:"varint,2,opt,name=lowBattery,proto3" json:"lowBattery,omitempty"`
+	OutOfFence           bool     `protobuf:"varint,3,opt,name=outOfFence,proto3" json:"outOfFence,omitempty"`
+	IntoFence            bool     `protobuf:"varint,4,opt,name=intoFence,proto3" json:"intoFence,omitempty"`
+	Worn                 bool     `protobuf:"varint,5,opt,name=worn,proto3" json:"worn,omitempty"`
+	Sos                  bool     `protobuf:"varint,6,opt,name=sos,proto3" json:"sos,omitempty"`
+	LowBatteryAlarm      bool     `protobuf:"varint,7,opt,name=lowBatteryAlarm,proto3" json:"lowBatteryAlarm,omitempty"`
+	OutOfFenceAlarm      bool     `protobuf:"varint,8,opt,name=outOfFenceAlarm,proto3" json:"outOfFenceAlarm,omitempty"`
+	IntoFenceAlarm       bool     `protobuf:"varint,9,opt,name=intoFenceAlarm,proto3" json:"intoFenceAlarm,omitempty"`
+	WatchRemoved         bool     `protobuf:"varint,10,opt,name=watchRemoved,proto3" json:"watchRemoved,omitempty"`
+	FallDown             bool     `protobuf:"varint,11,opt,name=fallDown,proto3" json:"fallDown,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Status) Reset()         { *m = Status{} }
+func (m *Status) String() string { return proto.CompactTextString(m) }
+func (*Status) ProtoMessage()    {}
+func (*Status) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bc56ba28a6aaff3d, []int{4}
+}
+
+func (m *Status) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Status.Unmarshal(m, b)
+}
+func (m *Status) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Status.Marshal(b, m, deterministic)
+}
+func (m *Status) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Status.Merge(m, src)
+}
+func (m *Status) XXX_Size() int {
+	return xxx_messageInfo_Status.Size(m)
+}
+func (m *Status) XXX_DiscardUnknown() {
+	xxx_messageInfo_Status.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Status proto.InternalMessageInfo
+
+func (m *Status) GetRaw() uint32 {
+	if m != nil {
+		return m.Raw
+	}
+	return 0
+}
+
+func (m *Status) GetLowBattery() bool {
+	if m != nil {
+		return m.LowBattery
+	}
+	return false
+}
+
+func (m *Status) GetOutOfFence() bool {
+	if m != nil {
+		return m.OutOfFence
+	}
+	return false
+}
+
+func (m *Status) GetIntoFence() bool {
+	if m != nil {
+		return m.IntoFence
+	}
+	return false
+}
+
+func (m *Status) GetWorn() bool {
+	if m != nil {
+		return m.Worn
+	}
+	return false
+}
+
+func (m *Status) GetSos() bool {
+	if m != nil {
+		return m.Sos
+	}
+	return false
+}
+
+func (m *Status) GetLowBatteryAlarm() bool {
+	if m != nil {
+		return m.LowBatteryAlarm
+	}
+	return false
+}
+
+func (m *Status) GetOutOfFenceAlarm() bool {
+	if m != nil {
+		return m.OutOfFenceAlarm
+	}
+	return false
+}
+
+func (m *Status) GetIntoFenceAlarm() bool {
+	if m != nil {
+		return m.IntoFenceAlarm
+	}
+	return false
+}
+
+func (m *Status) GetWatchRemoved() bool {
+	if m != nil {
+		return m.WatchRemoved
+	}
+	return false
+}
+
+func (m *Status) GetFallDown() bool {
+	if m != nil {
+		return m.FallDown
+	}
+	return false
+}
+
 type ServerCommand struct {
 	Version              string   `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
 	Command              string   `protobuf:"bytes,2,opt,name=command,proto3" json:"command,omitempty"`
@@ -184,7 +565,7 @@ func (m *ServerCommand) Reset()         { *m = ServerCommand{} }
 func (m *ServerCommand) String() string { return proto.CompactTextString(m) }
 func (*ServerCommand) ProtoMessage()    {}
 func (*ServerCommand) Descriptor() ([]byte, []int) {
-	return fileDescriptor_bc56ba28a6aaff3d, []int{2}
+	return fileDescriptor_bc56ba28a6aaff3d, []int{5}
 }
 
 func (m *ServerCommand) XXX_Unmarshal(b []byte) error {
@@ -231,7 +612,7 @@ func (m *ServerResponse) Reset()         { *m = ServerResponse{} }
 func (m *ServerResponse) String() string { return proto.CompactTextString(m) }
 func (*ServerResponse) ProtoMessage()    {}
 func (*ServerResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_bc56ba28a6aaff3d, []int{3}
+	return fileDescriptor_bc56ba28a6aaff3d, []int{6}
 }
 
 func (m *ServerResponse) XXX_Unmarshal(b []byte) error {
@@ -278,7 +659,7 @@ func (m *ServerResponse_Statistic) Reset()         { *m = ServerResponse_Statist
 func (m *ServerResponse_Statistic) String() string { return proto.CompactTextString(m) }
 func (*ServerResponse_Statistic) ProtoMessage()    {}
 func (*ServerResponse_Statistic) Descriptor() ([]byte, []int) {
-	return fileDescriptor_bc56ba28a6aaff3d, []int{3, 0}
+	return fileDescriptor_bc56ba28a6aaff3d, []int{6, 0}
 }
 
 func (m *ServerResponse_Statistic) XXX_Unmarshal(b []byte) error {
@@ -324,7 +705,7 @@ func (m *PingCommand) Reset()         { *m = PingCommand{} }
 func (m *PingCommand) String() string { return proto.CompactTextString(m) }
 func (*PingCommand) ProtoMessage()    {}
 func (*PingCommand) Descriptor() ([]byte, []int) {
-	return fileDescriptor_bc56ba28a6aaff3d, []int{4}
+	return fileDescriptor_bc56ba28a6aaff3d, []int{7}
 }
 
 func (m *PingCommand) XXX_Unmarshal(b []byte) error {
@@ -352,71 +733,1739 @@ func (m *PingCommand) GetMessage() string {
 	return ""
 }
 
-func init() {
-	proto.RegisterType((*Identifier)(nil), "api.Identifier")
-	proto.RegisterType((*Point)(nil), "api.Point")
-	proto.RegisterType((*ServerCommand)(nil), "api.ServerCommand")
-	proto.RegisterType((*ServerResponse)(nil), "api.ServerResponse")
-	proto.RegisterType((*ServerResponse_Statistic)(nil), "api.ServerResponse.Statistic")
-	proto.RegisterType((*PingCommand)(nil), "api.PingCommand")
+type CommandRequest struct {
+	Version              string   `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	DeviceId             string   `protobuf:"bytes,2,opt,name=deviceId,proto3" json:"deviceId,omitempty"`
+	Command              string   `protobuf:"bytes,3,opt,name=command,proto3" json:"command,omitempty"`
+	Args                 []string `protobuf:"bytes,4,rep,name=args,proto3" json:"args,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func init() { proto.RegisterFile("point_service.proto", fileDescriptor_bc56ba28a6aaff3d) }
-
-var fileDescriptor_bc56ba28a6aaff3d = []byte{
-	// 417 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x7c, 0x53, 0xb1, 0x6e, 0xdb, 0x30,
-	0x10, 0x35, 0x63, 0x3b, 0x8e, 0xce, 0x68, 0x12, 0x30, 0x1d, 0x08, 0xa1, 0x2d, 0x04, 0x0e, 0xad,
-	0x50, 0x14, 0x1e, 0x52, 0x74, 0xeb, 0xd4, 0x4c, 0x06, 0x3a, 0x18, 0x4a, 0xf6, 0x82, 0x91, 0xae,
-	0x06, 0x01, 0x9b, 0x14, 0xc8, 0xb3, 0x80, 0x7c, 0x4c, 0xb7, 0x8e, 0xfd, 0xc8, 0x42, 0xa4, 0x64,
-	0xa9, 0x49, 0xe1, 0x4d, 0xef, 0xdd, 0xdd, 0x7b, 0x8f, 0x3c, 0x0a, 0x6e, 0x6a, 0xab, 0x0d, 0xfd,
-	0xf0, 0xe8, 0x1a, 0x5d, 0xe2, 0xaa, 0x76, 0x96, 0x2c, 0x9f, 0xaa, 0x5a, 0xcb, 0x6f, 0x00, 0xeb,
-	0x0a, 0x0d, 0xe9, 0x9f, 0x1a, 0x1d, 0x17, 0xb0, 0x68, 0xd0, 0x79, 0x6d, 0x8d, 0x60, 0x19, 0xcb,
-	0x93, 0xa2, 0x87, 0x3c, 0x85, 0x8b, 0x72, 0xa7, 0xd1, 0xd0, 0xba, 0x12, 0x67, 0xa1, 0x74, 0xc4,
-	0xf2, 0xd7, 0x19, 0xcc, 0x37, 0xad, 0xc1, 0x89, 0xf9, 0x0c, 0x96, 0x7b, 0xf4, 0x5e, 0x6d, 0xf1,
-	0xe1, 0xa9, 0xc6, 0x4e, 0x62, 0x4c, 0xb5, 0xb3, 0x06, 0x29, 0x54, 0xa7, 0x71, 0xb6, 0x83, 0xad,
-	0x77, 0x85, 0x6d, 0xf0, 0x75, 0x25, 0x66, 0xd1, 0xbb, 0xc7, 0xfc, 0x3d, 0x5c, 0x3e, 0x2a, 0x22,
-	0x74, 0x4f, 0x1b, 0x74, 0x25, 0x1a, 0x12, 0xf3, 0x8c, 0xe5, 0x8b, 0xe2, 0x19, 0xdb, 0xfa, 0x3b,
-	0x2c, 0x51, 0x37, 0xf8, 0xa0, 0xf7, 0x28, 0xce, 0x33, 0x96, 0x4f, 0x8b, 0x31, 0xc5, 0xdf, 0x01,
-	0x44, 0xd5, 0xd0, 0xb0, 0x08, 0x0d, 0x23, 0xa6, 0x4d, 0xb1, 0x53, 0xa4, 0xe9, 0x50, 0xa1, 0xb8,
-	0xc8, 0x58, 0xce, 0x8a, 0x23, 0xe6, 0x6f, 0x20, 0xd9, 0x59, 0xb3, 0x8d, 0xc5, 0x24, 0x14, 0x07,
-	0x42, 0xde, 0xc1, 0xab, 0x7b, 0x74, 0x0d, 0xba, 0x3b, 0xbb, 0xdf, 0x2b, 0x53, 0x9d, 0xb8, 0x26,
-	0x01, 0x8b, 0x32, 0x36, 0x75, 0x57, 0xd4, 0x43, 0xf9, 0x87, 0xc1, 0x65, 0x54, 0x29, 0xd0, 0xd7,
-	0xd6, 0x78, 0x3c, 0x21, 0xb3, 0x86, 0xeb, 0xd8, 0x7b, 0x4f, 0x8a, 0xb4, 0x27, 0x5d, 0x7a, 0x31,
-	0xcb, 0xa6, 0xf9, 0xf2, 0xf6, 0xed, 0x4a, 0xd5, 0x7a, 0xf5, 0xaf, 0xd0, 0xea, 0xd8, 0x55, 0xbc,
-	0x18, 0x4b, 0xbf, 0x40, 0x72, 0x44, 0x9c, 0xc3, 0x8c, 0x86, 0xf5, 0x85, 0x6f, 0xfe, 0x1a, 0xe6,
-	0x8d, 0xda, 0x1d, 0xfa, 0xad, 0x45, 0x20, 0x3f, 0xc0, 0x72, 0xa3, 0xcd, 0x76, 0x74, 0xe2, 0x6e,
-	0xd7, 0x7d, 0xd4, 0x0e, 0xde, 0xfe, 0x66, 0x00, 0xce, 0x1e, 0x08, 0xe3, 0x0b, 0xfa, 0x08, 0xc9,
-	0x77, 0xe5, 0x29, 0x82, 0xab, 0x10, 0x76, 0x78, 0x9f, 0x29, 0x04, 0x22, 0x14, 0xe5, 0x84, 0x7f,
-	0x85, 0xab, 0x67, 0x71, 0x39, 0x1f, 0x1d, 0xaf, 0xf3, 0x4e, 0x6f, 0xfe, 0x73, 0x64, 0x39, 0xe1,
-	0x9f, 0x60, 0xd6, 0x26, 0xe4, 0xd7, 0x51, 0x73, 0x08, 0x9b, 0xbe, 0x60, 0xe4, 0xe4, 0xf1, 0x3c,
-	0xfc, 0x33, 0x9f, 0xff, 0x06, 0x00, 0x00, 0xff, 0xff, 0x59, 0xa8, 0x17, 0x14, 0x4a, 0x03, 0x00,
-	0x00,
+func (m *CommandRequest) Reset()         { *m = CommandRequest{} }
+func (m *CommandRequest) String() string { return proto.CompactTextString(m) }
+func (*CommandRequest) ProtoMessage()    {}
+func (*CommandRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bc56ba28a6aaff3d, []int{8}
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
-
-// RoutePointClient is the client API for RoutePoint service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type RoutePointClient interface {
-	LastPoint(ctx context.Context, in *Identifier, opts ...grpc.CallOption) (*Point, error)
-	ServerStatistic(ctx context.Context, in *ServerCommand, opts ...grpc.CallOption) (*ServerResponse, error)
-	Ping(ctx context.Context, in *PingCommand, opts ...grpc.CallOption) (*PingCommand, error)
+func (m *CommandRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommandRequest.Unmarshal(m, b)
 }
-
-type routePointClient struct {
-	cc *grpc.ClientConn
+func (m *CommandRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommandRequest.Marshal(b, m, deterministic)
 }
-
-func NewRoutePointClient(cc *grpc.ClientConn) RoutePointClient {
-	return &routePointClient{cc}
+func (m *CommandRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandRequest.Merge(m, src)
+}
+func (m *CommandRequest) XXX_Size() int {
+	return xxx_messageInfo_CommandRequest.Size(m)
+}
+func (m *CommandRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommandRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommandRequest proto.InternalMessageInfo
+
+func (m *CommandRequest) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *CommandRequest) GetDeviceId() string {
+	if m != nil {
+		return m.DeviceId
+	}
+	return ""
+}
+
+func (m *CommandRequest) GetCommand() string {
+	if m != nil {
+		return m.Command
+	}
+	return ""
+}
+
+func (m *CommandRequest) GetArgs() []string {
+	if m != nil {
+		return m.Args
+	}
+	return nil
+}
+
+type CommandIdentifier struct {
+	Version              string   `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	CommandId            uint64   `protobuf:"varint,2,opt,name=commandId,proto3" json:"commandId,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CommandIdentifier) Reset()         { *m = CommandIdentifier{} }
+func (m *CommandIdentifier) String() string { return proto.CompactTextString(m) }
+func (*CommandIdentifier) ProtoMessage()    {}
+func (*CommandIdentifier) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bc56ba28a6aaff3d, []int{9}
+}
+
+func (m *CommandIdentifier) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommandIdentifier.Unmarshal(m, b)
+}
+func (m *CommandIdentifier) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommandIdentifier.Marshal(b, m, deterministic)
+}
+func (m *CommandIdentifier) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandIdentifier.Merge(m, src)
+}
+func (m *CommandIdentifier) XXX_Size() int {
+	return xxx_messageInfo_CommandIdentifier.Size(m)
+}
+func (m *CommandIdentifier) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommandIdentifier.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommandIdentifier proto.InternalMessageInfo
+
+func (m *CommandIdentifier) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *CommandIdentifier) GetCommandId() uint64 {
+	if m != nil {
+		return m.CommandId
+	}
+	return 0
+}
+
+type CommandResponse struct {
+	Version              string   `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	CommandId            uint64   `protobuf:"varint,2,opt,name=commandId,proto3" json:"commandId,omitempty"`
+	DeviceId             string   `protobuf:"bytes,3,opt,name=deviceId,proto3" json:"deviceId,omitempty"`
+	Command              string   `protobuf:"bytes,4,opt,name=command,proto3" json:"command,omitempty"`
+	Status               string   `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	Reply                string   `protobuf:"bytes,6,opt,name=reply,proto3" json:"reply,omitempty"`
+	CreateTime           int64    `protobuf:"varint,7,opt,name=createTime,proto3" json:"createTime,omitempty"`
+	UpdateTime           int64    `protobuf:"varint,8,opt,name=updateTime,proto3" json:"updateTime,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CommandResponse) Reset()         { *m = CommandResponse{} }
+func (m *CommandResponse) String() string { return proto.CompactTextString(m) }
+func (*CommandResponse) ProtoMessage()    {}
+func (*CommandResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bc56ba28a6aaff3d, []int{10}
+}
+
+func (m *CommandResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommandResponse.Unmarshal(m, b)
+}
+func (m *CommandResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommandResponse.Marshal(b, m, deterministic)
+}
+func (m *CommandResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandResponse.Merge(m, src)
+}
+func (m *CommandResponse) XXX_Size() int {
+	return xxx_messageInfo_CommandResponse.Size(m)
+}
+func (m *CommandResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommandResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommandResponse proto.InternalMessageInfo
+
+func (m *CommandResponse) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *CommandResponse) GetCommandId() uint64 {
+	if m != nil {
+		return m.CommandId
+	}
+	return 0
+}
+
+func (m *CommandResponse) GetDeviceId() string {
+	if m != nil {
+		return m.DeviceId
+	}
+	return ""
+}
+
+func (m *CommandResponse) GetCommand() string {
+	if m != nil {
+		return m.Command
+	}
+	return ""
+}
+
+func (m *CommandResponse) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *CommandResponse) GetReply() string {
+	if m != nil {
+		return m.Reply
+	}
+	return ""
+}
+
+func (m *CommandResponse) GetCreateTime() int64 {
+	if m != nil {
+		return m.CreateTime
+	}
+	return 0
+}
+
+func (m *CommandResponse) GetUpdateTime() int64 {
+	if m != nil {
+		return m.UpdateTime
+	}
+	return 0
+}
+
+type DeviceConfig struct {
+	Version              string            `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	DeviceId             string            `protobuf:"bytes,2,opt,name=deviceId,proto3" json:"deviceId,omitempty"`
+	Model                string            `protobuf:"bytes,3,opt,name=model,proto3" json:"model,omitempty"`
+	UploadInterval       int64             `protobuf:"varint,4,opt,name=uploadInterval,proto3" json:"uploadInterval,omitempty"`
+	HeartRate            bool              `protobuf:"varint,5,opt,name=heartRate,proto3" json:"heartRate,omitempty"`
+	Wifi                 bool              `protobuf:"varint,6,opt,name=wifi,proto3" json:"wifi,omitempty"`
+	Video                bool              `protobuf:"varint,7,opt,name=video,proto3" json:"video,omitempty"`
+	Photo                bool              `protobuf:"varint,9,opt,name=photo,proto3" json:"photo,omitempty"`
+	Values               map[string]string `protobuf:"bytes,10,rep,name=values,proto3" json:"values,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *DeviceConfig) Reset()         { *m = DeviceConfig{} }
+func (m *DeviceConfig) String() string { return proto.CompactTextString(m) }
+func (*DeviceConfig) ProtoMessage()    {}
+func (*DeviceConfig) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bc56ba28a6aaff3d, []int{11}
+}
+
+func (m *DeviceConfig) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeviceConfig.Unmarshal(m, b)
+}
+func (m *DeviceConfig) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeviceConfig.Marshal(b, m, deterministic)
+}
+func (m *DeviceConfig) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeviceConfig.Merge(m, src)
+}
+func (m *DeviceConfig) XXX_Size() int {
+	return xxx_messageInfo_DeviceConfig.Size(m)
+}
+func (m *DeviceConfig) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeviceConfig.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeviceConfig proto.InternalMessageInfo
+
+func (m *DeviceConfig) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *DeviceConfig) GetDeviceId() string {
+	if m != nil {
+		return m.DeviceId
+	}
+	return ""
+}
+
+func (m *DeviceConfig) GetModel() string {
+	if m != nil {
+		return m.Model
+	}
+	return ""
+}
+
+func (m *DeviceConfig) GetUploadInterval() int64 {
+	if m != nil {
+		return m.UploadInterval
+	}
+	return 0
+}
+
+func (m *DeviceConfig) GetHeartRate() bool {
+	if m != nil {
+		return m.HeartRate
+	}
+	return false
+}
+
+func (m *DeviceConfig) GetWifi() bool {
+	if m != nil {
+		return m.Wifi
+	}
+	return false
+}
+
+func (m *DeviceConfig) GetVideo() bool {
+	if m != nil {
+		return m.Video
+	}
+	return false
+}
+
+func (m *DeviceConfig) GetPhoto() bool {
+	if m != nil {
+		return m.Photo
+	}
+	return false
+}
+
+func (m *DeviceConfig) GetValues() map[string]string {
+	if m != nil {
+		return m.Values
+	}
+	return nil
+}
+
+type AlarmRequest struct {
+	Version              string   `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	DeviceId             string   `protobuf:"bytes,2,opt,name=deviceId,proto3" json:"deviceId,omitempty"`
+	Unacknowledged       bool     `protobuf:"varint,3,opt,name=unacknowledged,proto3" json:"unacknowledged,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AlarmRequest) Reset()         { *m = AlarmRequest{} }
+func (m *AlarmRequest) String() string { return proto.CompactTextString(m) }
+func (*AlarmRequest) ProtoMessage()    {}
+func (*AlarmRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bc56ba28a6aaff3d, []int{12}
+}
+
+func (m *AlarmRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AlarmRequest.Unmarshal(m, b)
+}
+func (m *AlarmRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AlarmRequest.Marshal(b, m, deterministic)
+}
+func (m *AlarmRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AlarmRequest.Merge(m, src)
+}
+func (m *AlarmRequest) XXX_Size() int {
+	return xxx_messageInfo_AlarmRequest.Size(m)
+}
+func (m *AlarmRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AlarmRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AlarmRequest proto.InternalMessageInfo
+
+func (m *AlarmRequest) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *AlarmRequest) GetDeviceId() string {
+	if m != nil {
+		return m.DeviceId
+	}
+	return ""
+}
+
+func (m *AlarmRequest) GetUnacknowledged() bool {
+	if m != nil {
+		return m.Unacknowledged
+	}
+	return false
+}
+
+type AlarmIdentifier struct {
+	Version              string   `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	AlarmId              uint64   `protobuf:"varint,2,opt,name=alarmId,proto3" json:"alarmId,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AlarmIdentifier) Reset()         { *m = AlarmIdentifier{} }
+func (m *AlarmIdentifier) String() string { return proto.CompactTextString(m) }
+func (*AlarmIdentifier) ProtoMessage()    {}
+func (*AlarmIdentifier) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bc56ba28a6aaff3d, []int{13}
+}
+
+func (m *AlarmIdentifier) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AlarmIdentifier.Unmarshal(m, b)
+}
+func (m *AlarmIdentifier) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AlarmIdentifier.Marshal(b, m, deterministic)
+}
+func (m *AlarmIdentifier) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AlarmIdentifier.Merge(m, src)
+}
+func (m *AlarmIdentifier) XXX_Size() int {
+	return xxx_messageInfo_AlarmIdentifier.Size(m)
+}
+func (m *AlarmIdentifier) XXX_DiscardUnknown() {
+	xxx_messageInfo_AlarmIdentifier.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AlarmIdentifier proto.InternalMessageInfo
+
+func (m *AlarmIdentifier) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *AlarmIdentifier) GetAlarmId() uint64 {
+	if m != nil {
+		return m.AlarmId
+	}
+	return 0
+}
+
+type Alarm struct {
+	AlarmId              uint64   `protobuf:"varint,1,opt,name=alarmId,proto3" json:"alarmId,omitempty"`
+	DeviceId             string   `protobuf:"bytes,2,opt,name=deviceId,proto3" json:"deviceId,omitempty"`
+	Reason               string   `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	DeviceTime           int64    `protobuf:"varint,4,opt,name=deviceTime,proto3" json:"deviceTime,omitempty"`
+	ReceiveTime          int64    `protobuf:"varint,5,opt,name=receiveTime,proto3" json:"receiveTime,omitempty"`
+	Latitude             float64  `protobuf:"fixed64,6,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude            float64  `protobuf:"fixed64,7,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	Acknowledged         bool     `protobuf:"varint,8,opt,name=acknowledged,proto3" json:"acknowledged,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Alarm) Reset()         { *m = Alarm{} }
+func (m *Alarm) String() string { return proto.CompactTextString(m) }
+func (*Alarm) ProtoMessage()    {}
+func (*Alarm) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bc56ba28a6aaff3d, []int{14}
+}
+
+func (m *Alarm) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Alarm.Unmarshal(m, b)
+}
+func (m *Alarm) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Alarm.Marshal(b, m, deterministic)
+}
+func (m *Alarm) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Alarm.Merge(m, src)
+}
+func (m *Alarm) XXX_Size() int {
+	return xxx_messageInfo_Alarm.Size(m)
+}
+func (m *Alarm) XXX_DiscardUnknown() {
+	xxx_messageInfo_Alarm.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Alarm proto.InternalMessageInfo
+
+func (m *Alarm) GetAlarmId() uint64 {
+	if m != nil {
+		return m.AlarmId
+	}
+	return 0
+}
+
+func (m *Alarm) GetDeviceId() string {
+	if m != nil {
+		return m.DeviceId
+	}
+	return ""
+}
+
+func (m *Alarm) GetReason() string {
+	if m != nil {
+		return m.Reason
+	}
+	return ""
+}
+
+func (m *Alarm) GetDeviceTime() int64 {
+	if m != nil {
+		return m.DeviceTime
+	}
+	return 0
+}
+
+func (m *Alarm) GetReceiveTime() int64 {
+	if m != nil {
+		return m.ReceiveTime
+	}
+	return 0
+}
+
+func (m *Alarm) GetLatitude() float64 {
+	if m != nil {
+		return m.Latitude
+	}
+	return 0
+}
+
+func (m *Alarm) GetLongitude() float64 {
+	if m != nil {
+		return m.Longitude
+	}
+	return 0
+}
+
+func (m *Alarm) GetAcknowledged() bool {
+	if m != nil {
+		return m.Acknowledged
+	}
+	return false
+}
+
+type AlarmList struct {
+	Version              string   `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	Alarms               []*Alarm `protobuf:"bytes,2,rep,name=alarms,proto3" json:"alarms,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *AlarmList) Reset()         { *m = AlarmList{} }
+func (m *AlarmList) String() string { return proto.CompactTextString(m) }
+func (*AlarmList) ProtoMessage()    {}
+func (*AlarmList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bc56ba28a6aaff3d, []int{15}
+}
+
+func (m *AlarmList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AlarmList.Unmarshal(m, b)
+}
+func (m *AlarmList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AlarmList.Marshal(b, m, deterministic)
+}
+func (m *AlarmList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AlarmList.Merge(m, src)
+}
+func (m *AlarmList) XXX_Size() int {
+	return xxx_messageInfo_AlarmList.Size(m)
+}
+func (m *AlarmList) XXX_DiscardUnknown() {
+	xxx_messageInfo_AlarmList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AlarmList proto.InternalMessageInfo
+
+func (m *AlarmList) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *AlarmList) GetAlarms() []*Alarm {
+	if m != nil {
+		return m.Alarms
+	}
+	return nil
+}
+
+type HealthRequest struct {
+	Version              string   `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	DeviceId             string   `protobuf:"bytes,2,opt,name=deviceId,proto3" json:"deviceId,omitempty"`
+	From                 int64    `protobuf:"varint,3,opt,name=from,proto3" json:"from,omitempty"`
+	To                   int64    `protobuf:"varint,4,opt,name=to,proto3" json:"to,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HealthRequest) Reset()         { *m = HealthRequest{} }
+func (m *HealthRequest) String() string { return proto.CompactTextString(m) }
+func (*HealthRequest) ProtoMessage()    {}
+func (*HealthRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bc56ba28a6aaff3d, []int{16}
+}
+
+func (m *HealthRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HealthRequest.Unmarshal(m, b)
+}
+func (m *HealthRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HealthRequest.Marshal(b, m, deterministic)
+}
+func (m *HealthRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HealthRequest.Merge(m, src)
+}
+func (m *HealthRequest) XXX_Size() int {
+	return xxx_messageInfo_HealthRequest.Size(m)
+}
+func (m *HealthRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_HealthRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HealthRequest proto.InternalMessageInfo
+
+func (m *HealthRequest) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *HealthRequest) GetDeviceId() string {
+	if m != nil {
+		return m.DeviceId
+	}
+	return ""
+}
+
+func (m *HealthRequest) GetFrom() int64 {
+	if m != nil {
+		return m.From
+	}
+	return 0
+}
+
+func (m *HealthRequest) GetTo() int64 {
+	if m != nil {
+		return m.To
+	}
+	return 0
+}
+
+type Health struct {
+	Time                 int64    `protobuf:"varint,1,opt,name=time,proto3" json:"time,omitempty"`
+	HeartRate            uint32   `protobuf:"fixed32,2,opt,name=heartRate,proto3" json:"heartRate,omitempty"`
+	Systolic             uint32   `protobuf:"fixed32,3,opt,name=systolic,proto3" json:"systolic,omitempty"`
+	Diastolic            uint32   `protobuf:"fixed32,4,opt,name=diastolic,proto3" json:"diastolic,omitempty"`
+	Spo2                 uint32   `protobuf:"fixed32,5,opt,name=spo2,proto3" json:"spo2,omitempty"`
+	Temperature          float64  `protobuf:"fixed64,6,opt,name=temperature,proto3" json:"temperature,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Health) Reset()         { *m = Health{} }
+func (m *Health) String() string { return proto.CompactTextString(m) }
+func (*Health) ProtoMessage()    {}
+func (*Health) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bc56ba28a6aaff3d, []int{17}
+}
+
+func (m *Health) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Health.Unmarshal(m, b)
+}
+func (m *Health) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Health.Marshal(b, m, deterministic)
+}
+func (m *Health) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Health.Merge(m, src)
+}
+func (m *Health) XXX_Size() int {
+	return xxx_messageInfo_Health.Size(m)
+}
+func (m *Health) XXX_DiscardUnknown() {
+	xxx_messageInfo_Health.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Health proto.InternalMessageInfo
+
+func (m *Health) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *Health) GetHeartRate() uint32 {
+	if m != nil {
+		return m.HeartRate
+	}
+	return 0
+}
+
+func (m *Health) GetSystolic() uint32 {
+	if m != nil {
+		return m.Systolic
+	}
+	return 0
+}
+
+func (m *Health) GetDiastolic() uint32 {
+	if m != nil {
+		return m.Diastolic
+	}
+	return 0
+}
+
+func (m *Health) GetSpo2() uint32 {
+	if m != nil {
+		return m.Spo2
+	}
+	return 0
+}
+
+func (m *Health) GetTemperature() float64 {
+	if m != nil {
+		return m.Temperature
+	}
+	return 0
+}
+
+type HealthList struct {
+	Version              string    `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	DeviceId             string    `protobuf:"bytes,2,opt,name=deviceId,proto3" json:"deviceId,omitempty"`
+	Measurements         []*Health `protobuf:"bytes,3,rep,name=measurements,proto3" json:"measurements,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *HealthList) Reset()         { *m = HealthList{} }
+func (m *HealthList) String() string { return proto.CompactTextString(m) }
+func (*HealthList) ProtoMessage()    {}
+func (*HealthList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bc56ba28a6aaff3d, []int{18}
+}
+
+func (m *HealthList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HealthList.Unmarshal(m, b)
+}
+func (m *HealthList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HealthList.Marshal(b, m, deterministic)
+}
+func (m *HealthList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HealthList.Merge(m, src)
+}
+func (m *HealthList) XXX_Size() int {
+	return xxx_messageInfo_HealthList.Size(m)
+}
+func (m *HealthList) XXX_DiscardUnknown() {
+	xxx_messageInfo_HealthList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HealthList proto.InternalMessageInfo
+
+func (m *HealthList) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *HealthList) GetDeviceId() string {
+	if m != nil {
+		return m.DeviceId
+	}
+	return ""
+}
+
+func (m *HealthList) GetMeasurements() []*Health {
+	if m != nil {
+		return m.Measurements
+	}
+	return nil
+}
+
+type MediaIdentifier struct {
+	Version              string   `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	DeviceId             string   `protobuf:"bytes,2,opt,name=deviceId,proto3" json:"deviceId,omitempty"`
+	Name                 string   `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MediaIdentifier) Reset()         { *m = MediaIdentifier{} }
+func (m *MediaIdentifier) String() string { return proto.CompactTextString(m) }
+func (*MediaIdentifier) ProtoMessage()    {}
+func (*MediaIdentifier) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bc56ba28a6aaff3d, []int{19}
+}
+
+func (m *MediaIdentifier) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MediaIdentifier.Unmarshal(m, b)
+}
+func (m *MediaIdentifier) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MediaIdentifier.Marshal(b, m, deterministic)
+}
+func (m *MediaIdentifier) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MediaIdentifier.Merge(m, src)
+}
+func (m *MediaIdentifier) XXX_Size() int {
+	return xxx_messageInfo_MediaIdentifier.Size(m)
+}
+func (m *MediaIdentifier) XXX_DiscardUnknown() {
+	xxx_messageInfo_MediaIdentifier.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MediaIdentifier proto.InternalMessageInfo
+
+func (m *MediaIdentifier) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *MediaIdentifier) GetDeviceId() string {
+	if m != nil {
+		return m.DeviceId
+	}
+	return ""
+}
+
+func (m *MediaIdentifier) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type MediaFile struct {
+	DeviceId             string   `protobuf:"bytes,1,opt,name=deviceId,proto3" json:"deviceId,omitempty"`
+	Name                 string   `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Time                 int64    `protobuf:"varint,3,opt,name=time,proto3" json:"time,omitempty"`
+	Size                 int64    `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *MediaFile) Reset()         { *m = MediaFile{} }
+func (m *MediaFile) String() string { return proto.CompactTextString(m) }
+func (*MediaFile) ProtoMessage()    {}
+func (*MediaFile) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bc56ba28a6aaff3d, []int{20}
+}
+
+func (m *MediaFile) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MediaFile.Unmarshal(m, b)
+}
+func (m *MediaFile) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MediaFile.Marshal(b, m, deterministic)
+}
+func (m *MediaFile) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MediaFile.Merge(m, src)
+}
+func (m *MediaFile) XXX_Size() int {
+	return xxx_messageInfo_MediaFile.Size(m)
+}
+func (m *MediaFile) XXX_DiscardUnknown() {
+	xxx_messageInfo_MediaFile.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MediaFile proto.InternalMessageInfo
+
+func (m *MediaFile) GetDeviceId() string {
+	if m != nil {
+		return m.DeviceId
+	}
+	return ""
+}
+
+func (m *MediaFile) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *MediaFile) GetTime() int64 {
+	if m != nil {
+		return m.Time
+	}
+	return 0
+}
+
+func (m *MediaFile) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+type MediaList struct {
+	Version              string       `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	Files                []*MediaFile `protobuf:"bytes,2,rep,name=files,proto3" json:"files,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}     `json:"-"`
+	XXX_unrecognized     []byte       `json:"-"`
+	XXX_sizecache        int32        `json:"-"`
+}
+
+func (m *MediaList) Reset()         { *m = MediaList{} }
+func (m *MediaList) String() string { return proto.CompactTextString(m) }
+func (*MediaList) ProtoMessage()    {}
+func (*MediaList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bc56ba28a6aaff3d, []int{21}
+}
+
+func (m *MediaList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_MediaList.Unmarshal(m, b)
+}
+func (m *MediaList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_MediaList.Marshal(b, m, deterministic)
+}
+func (m *MediaList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_MediaList.Merge(m, src)
+}
+func (m *MediaList) XXX_Size() int {
+	return xxx_messageInfo_MediaList.Size(m)
+}
+func (m *MediaList) XXX_DiscardUnknown() {
+	xxx_messageInfo_MediaList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_MediaList proto.InternalMessageInfo
+
+func (m *MediaList) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *MediaList) GetFiles() []*MediaFile {
+	if m != nil {
+		return m.Files
+	}
+	return nil
+}
+
+type Media struct {
+	Version              string     `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	File                 *MediaFile `protobuf:"bytes,2,opt,name=file,proto3" json:"file,omitempty"`
+	Data                 []byte     `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *Media) Reset()         { *m = Media{} }
+func (m *Media) String() string { return proto.CompactTextString(m) }
+func (*Media) ProtoMessage()    {}
+func (*Media) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bc56ba28a6aaff3d, []int{22}
+}
+
+func (m *Media) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Media.Unmarshal(m, b)
+}
+func (m *Media) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Media.Marshal(b, m, deterministic)
+}
+func (m *Media) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Media.Merge(m, src)
+}
+func (m *Media) XXX_Size() int {
+	return xxx_messageInfo_Media.Size(m)
+}
+func (m *Media) XXX_DiscardUnknown() {
+	xxx_messageInfo_Media.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Media proto.InternalMessageInfo
+
+func (m *Media) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *Media) GetFile() *MediaFile {
+	if m != nil {
+		return m.File
+	}
+	return nil
+}
+
+func (m *Media) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type VoiceRequest struct {
+	Version              string   `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	DeviceId             string   `protobuf:"bytes,2,opt,name=deviceId,proto3" json:"deviceId,omitempty"`
+	Data                 []byte   `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *VoiceRequest) Reset()         { *m = VoiceRequest{} }
+func (m *VoiceRequest) String() string { return proto.CompactTextString(m) }
+func (*VoiceRequest) ProtoMessage()    {}
+func (*VoiceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bc56ba28a6aaff3d, []int{23}
+}
+
+func (m *VoiceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_VoiceRequest.Unmarshal(m, b)
+}
+func (m *VoiceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_VoiceRequest.Marshal(b, m, deterministic)
+}
+func (m *VoiceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VoiceRequest.Merge(m, src)
+}
+func (m *VoiceRequest) XXX_Size() int {
+	return xxx_messageInfo_VoiceRequest.Size(m)
+}
+func (m *VoiceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_VoiceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VoiceRequest proto.InternalMessageInfo
+
+func (m *VoiceRequest) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *VoiceRequest) GetDeviceId() string {
+	if m != nil {
+		return m.DeviceId
+	}
+	return ""
+}
+
+func (m *VoiceRequest) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type CommandList struct {
+	Version              string             `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	Commands             []*CommandResponse `protobuf:"bytes,2,rep,name=commands,proto3" json:"commands,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *CommandList) Reset()         { *m = CommandList{} }
+func (m *CommandList) String() string { return proto.CompactTextString(m) }
+func (*CommandList) ProtoMessage()    {}
+func (*CommandList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bc56ba28a6aaff3d, []int{24}
+}
+
+func (m *CommandList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CommandList.Unmarshal(m, b)
+}
+func (m *CommandList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CommandList.Marshal(b, m, deterministic)
+}
+func (m *CommandList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CommandList.Merge(m, src)
+}
+func (m *CommandList) XXX_Size() int {
+	return xxx_messageInfo_CommandList.Size(m)
+}
+func (m *CommandList) XXX_DiscardUnknown() {
+	xxx_messageInfo_CommandList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CommandList proto.InternalMessageInfo
+
+func (m *CommandList) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *CommandList) GetCommands() []*CommandResponse {
+	if m != nil {
+		return m.Commands
+	}
+	return nil
+}
+
+type SessionRequest struct {
+	Version              string   `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	OnlineOnly           bool     `protobuf:"varint,2,opt,name=onlineOnly,proto3" json:"onlineOnly,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SessionRequest) Reset()         { *m = SessionRequest{} }
+func (m *SessionRequest) String() string { return proto.CompactTextString(m) }
+func (*SessionRequest) ProtoMessage()    {}
+func (*SessionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bc56ba28a6aaff3d, []int{25}
+}
+
+func (m *SessionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SessionRequest.Unmarshal(m, b)
+}
+func (m *SessionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SessionRequest.Marshal(b, m, deterministic)
+}
+func (m *SessionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SessionRequest.Merge(m, src)
+}
+func (m *SessionRequest) XXX_Size() int {
+	return xxx_messageInfo_SessionRequest.Size(m)
+}
+func (m *SessionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SessionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SessionRequest proto.InternalMessageInfo
+
+func (m *SessionRequest) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *SessionRequest) GetOnlineOnly() bool {
+	if m != nil {
+		return m.OnlineOnly
+	}
+	return false
+}
+
+type Session struct {
+	DeviceId             string            `protobuf:"bytes,1,opt,name=deviceId,proto3" json:"deviceId,omitempty"`
+	Online               bool              `protobuf:"varint,2,opt,name=online,proto3" json:"online,omitempty"`
+	RemoteAddr           string            `protobuf:"bytes,3,opt,name=remoteAddr,proto3" json:"remoteAddr,omitempty"`
+	ConnectTime          int64             `protobuf:"varint,4,opt,name=connectTime,proto3" json:"connectTime,omitempty"`
+	LastSeen             int64             `protobuf:"varint,5,opt,name=lastSeen,proto3" json:"lastSeen,omitempty"`
+	DisconnectTime       int64             `protobuf:"varint,6,opt,name=disconnectTime,proto3" json:"disconnectTime,omitempty"`
+	Frames               uint64            `protobuf:"fixed64,7,opt,name=frames,proto3" json:"frames,omitempty"`
+	Bytes                uint64            `protobuf:"fixed64,8,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	Reconnects           uint64            `protobuf:"fixed64,9,opt,name=reconnects,proto3" json:"reconnects,omitempty"`
+	Messages             map[string]uint64 `protobuf:"bytes,10,rep,name=messages,proto3" json:"messages,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"fixed64,2,opt,name=value,proto3"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *Session) Reset()         { *m = Session{} }
+func (m *Session) String() string { return proto.CompactTextString(m) }
+func (*Session) ProtoMessage()    {}
+func (*Session) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bc56ba28a6aaff3d, []int{26}
+}
+
+func (m *Session) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Session.Unmarshal(m, b)
+}
+func (m *Session) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Session.Marshal(b, m, deterministic)
+}
+func (m *Session) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Session.Merge(m, src)
+}
+func (m *Session) XXX_Size() int {
+	return xxx_messageInfo_Session.Size(m)
+}
+func (m *Session) XXX_DiscardUnknown() {
+	xxx_messageInfo_Session.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Session proto.InternalMessageInfo
+
+func (m *Session) GetDeviceId() string {
+	if m != nil {
+		return m.DeviceId
+	}
+	return ""
+}
+
+func (m *Session) GetOnline() bool {
+	if m != nil {
+		return m.Online
+	}
+	return false
+}
+
+func (m *Session) GetRemoteAddr() string {
+	if m != nil {
+		return m.RemoteAddr
+	}
+	return ""
+}
+
+func (m *Session) GetConnectTime() int64 {
+	if m != nil {
+		return m.ConnectTime
+	}
+	return 0
+}
+
+func (m *Session) GetLastSeen() int64 {
+	if m != nil {
+		return m.LastSeen
+	}
+	return 0
+}
+
+func (m *Session) GetDisconnectTime() int64 {
+	if m != nil {
+		return m.DisconnectTime
+	}
+	return 0
+}
+
+func (m *Session) GetFrames() uint64 {
+	if m != nil {
+		return m.Frames
+	}
+	return 0
+}
+
+func (m *Session) GetBytes() uint64 {
+	if m != nil {
+		return m.Bytes
+	}
+	return 0
+}
+
+func (m *Session) GetReconnects() uint64 {
+	if m != nil {
+		return m.Reconnects
+	}
+	return 0
+}
+
+func (m *Session) GetMessages() map[string]uint64 {
+	if m != nil {
+		return m.Messages
+	}
+	return nil
+}
+
+type SessionList struct {
+	Version              string     `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	Sessions             []*Session `protobuf:"bytes,2,rep,name=sessions,proto3" json:"sessions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *SessionList) Reset()         { *m = SessionList{} }
+func (m *SessionList) String() string { return proto.CompactTextString(m) }
+func (*SessionList) ProtoMessage()    {}
+func (*SessionList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bc56ba28a6aaff3d, []int{27}
+}
+
+func (m *SessionList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SessionList.Unmarshal(m, b)
+}
+func (m *SessionList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SessionList.Marshal(b, m, deterministic)
+}
+func (m *SessionList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SessionList.Merge(m, src)
+}
+func (m *SessionList) XXX_Size() int {
+	return xxx_messageInfo_SessionList.Size(m)
+}
+func (m *SessionList) XXX_DiscardUnknown() {
+	xxx_messageInfo_SessionList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SessionList proto.InternalMessageInfo
+
+func (m *SessionList) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *SessionList) GetSessions() []*Session {
+	if m != nil {
+		return m.Sessions
+	}
+	return nil
+}
+
+type SubscribeRequest struct {
+	Version              string   `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	DeviceIds            []string `protobuf:"bytes,2,rep,name=deviceIds,proto3" json:"deviceIds,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SubscribeRequest) Reset()         { *m = SubscribeRequest{} }
+func (m *SubscribeRequest) String() string { return proto.CompactTextString(m) }
+func (*SubscribeRequest) ProtoMessage()    {}
+func (*SubscribeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bc56ba28a6aaff3d, []int{28}
+}
+
+func (m *SubscribeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubscribeRequest.Unmarshal(m, b)
+}
+func (m *SubscribeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubscribeRequest.Marshal(b, m, deterministic)
+}
+func (m *SubscribeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscribeRequest.Merge(m, src)
+}
+func (m *SubscribeRequest) XXX_Size() int {
+	return xxx_messageInfo_SubscribeRequest.Size(m)
+}
+func (m *SubscribeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscribeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubscribeRequest proto.InternalMessageInfo
+
+func (m *SubscribeRequest) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *SubscribeRequest) GetDeviceIds() []string {
+	if m != nil {
+		return m.DeviceIds
+	}
+	return nil
+}
+
+type HistoryRequest struct {
+	Version              string   `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	DeviceId             string   `protobuf:"bytes,2,opt,name=deviceId,proto3" json:"deviceId,omitempty"`
+	From                 int64    `protobuf:"varint,3,opt,name=from,proto3" json:"from,omitempty"`
+	To                   int64    `protobuf:"varint,4,opt,name=to,proto3" json:"to,omitempty"`
+	Limit                uint32   `protobuf:"fixed32,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	PageToken            string   `protobuf:"bytes,6,opt,name=pageToken,proto3" json:"pageToken,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HistoryRequest) Reset()         { *m = HistoryRequest{} }
+func (m *HistoryRequest) String() string { return proto.CompactTextString(m) }
+func (*HistoryRequest) ProtoMessage()    {}
+func (*HistoryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bc56ba28a6aaff3d, []int{29}
+}
+
+func (m *HistoryRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HistoryRequest.Unmarshal(m, b)
+}
+func (m *HistoryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HistoryRequest.Marshal(b, m, deterministic)
+}
+func (m *HistoryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HistoryRequest.Merge(m, src)
+}
+func (m *HistoryRequest) XXX_Size() int {
+	return xxx_messageInfo_HistoryRequest.Size(m)
+}
+func (m *HistoryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_HistoryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HistoryRequest proto.InternalMessageInfo
+
+func (m *HistoryRequest) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *HistoryRequest) GetDeviceId() string {
+	if m != nil {
+		return m.DeviceId
+	}
+	return ""
+}
+
+func (m *HistoryRequest) GetFrom() int64 {
+	if m != nil {
+		return m.From
+	}
+	return 0
+}
+
+func (m *HistoryRequest) GetTo() int64 {
+	if m != nil {
+		return m.To
+	}
+	return 0
+}
+
+func (m *HistoryRequest) GetLimit() uint32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *HistoryRequest) GetPageToken() string {
+	if m != nil {
+		return m.PageToken
+	}
+	return ""
+}
+
+type HistoryResponse struct {
+	Version              string   `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	DeviceId             string   `protobuf:"bytes,2,opt,name=deviceId,proto3" json:"deviceId,omitempty"`
+	Points               []*Point `protobuf:"bytes,3,rep,name=points,proto3" json:"points,omitempty"`
+	NextPageToken        string   `protobuf:"bytes,4,opt,name=nextPageToken,proto3" json:"nextPageToken,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HistoryResponse) Reset()         { *m = HistoryResponse{} }
+func (m *HistoryResponse) String() string { return proto.CompactTextString(m) }
+func (*HistoryResponse) ProtoMessage()    {}
+func (*HistoryResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bc56ba28a6aaff3d, []int{30}
+}
+
+func (m *HistoryResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HistoryResponse.Unmarshal(m, b)
+}
+func (m *HistoryResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HistoryResponse.Marshal(b, m, deterministic)
+}
+func (m *HistoryResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HistoryResponse.Merge(m, src)
+}
+func (m *HistoryResponse) XXX_Size() int {
+	return xxx_messageInfo_HistoryResponse.Size(m)
+}
+func (m *HistoryResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_HistoryResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HistoryResponse proto.InternalMessageInfo
+
+func (m *HistoryResponse) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *HistoryResponse) GetDeviceId() string {
+	if m != nil {
+		return m.DeviceId
+	}
+	return ""
+}
+
+func (m *HistoryResponse) GetPoints() []*Point {
+	if m != nil {
+		return m.Points
+	}
+	return nil
+}
+
+func (m *HistoryResponse) GetNextPageToken() string {
+	if m != nil {
+		return m.NextPageToken
+	}
+	return ""
+}
+
+type TimeZone struct {
+	Version              string   `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	DeviceId             string   `protobuf:"bytes,2,opt,name=deviceId,proto3" json:"deviceId,omitempty"`
+	Offset               string   `protobuf:"bytes,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TimeZone) Reset()         { *m = TimeZone{} }
+func (m *TimeZone) String() string { return proto.CompactTextString(m) }
+func (*TimeZone) ProtoMessage()    {}
+func (*TimeZone) Descriptor() ([]byte, []int) {
+	return fileDescriptor_bc56ba28a6aaff3d, []int{31}
+}
+
+func (m *TimeZone) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TimeZone.Unmarshal(m, b)
+}
+func (m *TimeZone) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TimeZone.Marshal(b, m, deterministic)
+}
+func (m *TimeZone) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TimeZone.Merge(m, src)
+}
+func (m *TimeZone) XXX_Size() int {
+	return xxx_messageInfo_TimeZone.Size(m)
+}
+func (m *TimeZone) XXX_DiscardUnknown() {
+	xxx_messageInfo_TimeZone.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TimeZone proto.InternalMessageInfo
+
+func (m *TimeZone) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *TimeZone) GetDeviceId() string {
+	if m != nil {
+		return m.DeviceId
+	}
+	return ""
+}
+
+func (m *TimeZone) GetOffset() string {
+	if m != nil {
+		return m.Offset
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterType((*Identifier)(nil), "api.Identifier")
+	proto.RegisterType((*Point)(nil), "api.Point")
+	proto.RegisterType((*CellTower)(nil), "api.CellTower")
+	proto.RegisterType((*WifiAP)(nil), "api.WifiAP")
+	proto.RegisterType((*Status)(nil), "api.Status")
+	proto.RegisterType((*ServerCommand)(nil), "api.ServerCommand")
+	proto.RegisterType((*ServerResponse)(nil), "api.ServerResponse")
+	proto.RegisterType((*ServerResponse_Statistic)(nil), "api.ServerResponse.Statistic")
+	proto.RegisterType((*PingCommand)(nil), "api.PingCommand")
+	proto.RegisterType((*CommandRequest)(nil), "api.CommandRequest")
+	proto.RegisterType((*CommandIdentifier)(nil), "api.CommandIdentifier")
+	proto.RegisterType((*CommandResponse)(nil), "api.CommandResponse")
+	proto.RegisterType((*DeviceConfig)(nil), "api.DeviceConfig")
+	proto.RegisterMapType((map[string]string)(nil), "api.DeviceConfig.ValuesEntry")
+	proto.RegisterType((*AlarmRequest)(nil), "api.AlarmRequest")
+	proto.RegisterType((*AlarmIdentifier)(nil), "api.AlarmIdentifier")
+	proto.RegisterType((*Alarm)(nil), "api.Alarm")
+	proto.RegisterType((*AlarmList)(nil), "api.AlarmList")
+	proto.RegisterType((*HealthRequest)(nil), "api.HealthRequest")
+	proto.RegisterType((*Health)(nil), "api.Health")
+	proto.RegisterType((*HealthList)(nil), "api.HealthList")
+	proto.RegisterType((*MediaIdentifier)(nil), "api.MediaIdentifier")
+	proto.RegisterType((*MediaFile)(nil), "api.MediaFile")
+	proto.RegisterType((*MediaList)(nil), "api.MediaList")
+	proto.RegisterType((*Media)(nil), "api.Media")
+	proto.RegisterType((*VoiceRequest)(nil), "api.VoiceRequest")
+	proto.RegisterType((*CommandList)(nil), "api.CommandList")
+	proto.RegisterType((*SessionRequest)(nil), "api.SessionRequest")
+	proto.RegisterType((*Session)(nil), "api.Session")
+	proto.RegisterMapType((map[string]uint64)(nil), "api.Session.MessagesEntry")
+	proto.RegisterType((*SessionList)(nil), "api.SessionList")
+	proto.RegisterType((*SubscribeRequest)(nil), "api.SubscribeRequest")
+	proto.RegisterType((*HistoryRequest)(nil), "api.HistoryRequest")
+	proto.RegisterType((*HistoryResponse)(nil), "api.HistoryResponse")
+	proto.RegisterType((*TimeZone)(nil), "api.TimeZone")
+}
+
+func init() { proto.RegisterFile("point_service.proto", fileDescriptor_bc56ba28a6aaff3d) }
+
+var fileDescriptor_bc56ba28a6aaff3d = []byte{
+	// 2041 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xb4, 0x58, 0x5f, 0x6f, 0x24, 0x47,
+	0x11, 0xf7, 0xfe, 0xdf, 0x2d, 0xaf, 0xbd, 0xbe, 0xb1, 0x63, 0x86, 0x15, 0x20, 0xab, 0x09, 0x60,
+	0x21, 0xe2, 0x44, 0x46, 0xb9, 0x84, 0x80, 0x84, 0xee, 0x7c, 0x09, 0xf1, 0xe5, 0xa2, 0x33, 0xe3,
+	0xd3, 0x91, 0x84, 0x07, 0xd4, 0x9e, 0xe9, 0xb5, 0x5b, 0x9e, 0x9d, 0x5e, 0xa6, 0x7b, 0xbd, 0x98,
+	0x0f, 0xc0, 0x3b, 0x5f, 0x00, 0x89, 0x57, 0xc4, 0x07, 0x42, 0xbc, 0xf1, 0x01, 0x78, 0xe4, 0x1d,
+	0x55, 0x75, 0xcf, 0x4c, 0xcf, 0xee, 0xdd, 0xda, 0xba, 0x53, 0xde, 0xba, 0x7e, 0x55, 0xdd, 0x5d,
+	0x5d, 0xff, 0x67, 0x60, 0x77, 0xa6, 0x64, 0x66, 0xfe, 0xa0, 0x45, 0x7e, 0x23, 0x63, 0x71, 0x34,
+	0xcb, 0x95, 0x51, 0x41, 0x8b, 0xcf, 0x24, 0x7b, 0x0c, 0x70, 0x9a, 0x88, 0xcc, 0xc8, 0x89, 0x14,
+	0x79, 0x10, 0x42, 0xef, 0x46, 0xe4, 0x5a, 0xaa, 0x2c, 0x6c, 0x1c, 0x34, 0x0e, 0x07, 0x51, 0x41,
+	0x06, 0x63, 0xe8, 0xc7, 0xa9, 0x14, 0x99, 0x39, 0x4d, 0xc2, 0x26, 0xb1, 0x4a, 0x9a, 0xfd, 0xbd,
+	0x0b, 0x9d, 0x33, 0xbc, 0x60, 0xcd, 0xfe, 0x03, 0xd8, 0x9c, 0x0a, 0xad, 0xf9, 0xa5, 0x78, 0x71,
+	0x3b, 0x13, 0xee, 0x08, 0x1f, 0xc2, 0xbd, 0x99, 0x30, 0xc4, 0x6d, 0xd9, 0xbd, 0x8e, 0xc4, 0xbb,
+	0x13, 0x81, 0x8a, 0x9f, 0x26, 0x61, 0xdb, 0xde, 0x5d, 0xd0, 0xc1, 0x8f, 0x61, 0xfb, 0x82, 0x1b,
+	0x23, 0xf2, 0xdb, 0x33, 0x91, 0xc7, 0x22, 0x33, 0x61, 0xe7, 0xa0, 0x71, 0xd8, 0x8b, 0x96, 0x50,
+	0xbc, 0x3f, 0x17, 0xb1, 0x90, 0x37, 0xe2, 0x85, 0x9c, 0x8a, 0xb0, 0x7b, 0xd0, 0x38, 0x6c, 0x45,
+	0x3e, 0x14, 0xfc, 0x00, 0xc0, 0x9e, 0x4a, 0x02, 0x3d, 0x12, 0xf0, 0x10, 0xd4, 0x22, 0xe5, 0x46,
+	0x9a, 0x79, 0x22, 0xc2, 0xfe, 0x41, 0xe3, 0xb0, 0x11, 0x95, 0x74, 0xf0, 0x3d, 0x18, 0xa4, 0x2a,
+	0xbb, 0xb4, 0xcc, 0x01, 0x31, 0x2b, 0x00, 0x77, 0x5e, 0xce, 0xf4, 0x4b, 0x9e, 0xca, 0x24, 0x84,
+	0x83, 0xc6, 0x61, 0x3f, 0x2a, 0xe9, 0x60, 0x0f, 0x3a, 0x7a, 0x26, 0x44, 0x12, 0x6e, 0xd2, 0x2e,
+	0x4b, 0x04, 0xfb, 0xd0, 0x8d, 0xd5, 0x3c, 0xd7, 0x22, 0x1c, 0x12, 0xec, 0x28, 0x3c, 0x89, 0xa7,
+	0x4e, 0x87, 0x2d, 0xab, 0x43, 0x41, 0xa3, 0xfe, 0x9a, 0x1b, 0x91, 0xa6, 0xd2, 0x08, 0x1d, 0x6e,
+	0x93, 0x15, 0x3c, 0x04, 0x2d, 0xa5, 0xe5, 0x65, 0xc6, 0xd3, 0x73, 0x93, 0x8b, 0xec, 0xd2, 0x5c,
+	0x85, 0x23, 0x6b, 0xa9, 0x3a, 0x4a, 0x1a, 0x19, 0x31, 0xd3, 0xe1, 0x0e, 0xb1, 0x2d, 0x81, 0xde,
+	0x31, 0xf3, 0xe9, 0x45, 0x2a, 0x74, 0xf8, 0x80, 0xf0, 0x82, 0xc4, 0x7b, 0xaf, 0xa4, 0x36, 0x2a,
+	0x97, 0x31, 0x4f, 0xc3, 0x80, 0xde, 0xe7, 0x21, 0xc1, 0x0f, 0xa1, 0xab, 0x0d, 0x37, 0x73, 0x1d,
+	0xee, 0x1e, 0x34, 0x0e, 0x37, 0x8f, 0x37, 0x8f, 0xf8, 0x4c, 0x1e, 0x9d, 0x13, 0x14, 0x39, 0x56,
+	0x70, 0x04, 0x10, 0x8b, 0x34, 0x7d, 0xa1, 0x16, 0x22, 0xd7, 0xe1, 0xde, 0x41, 0xeb, 0x70, 0xf3,
+	0x78, 0x9b, 0x04, 0x4f, 0x0a, 0x38, 0xf2, 0x24, 0x82, 0x1f, 0x41, 0x6f, 0x21, 0x27, 0xf2, 0xd1,
+	0x99, 0x0e, 0xdf, 0x39, 0x68, 0x95, 0xa7, 0xfe, 0x8e, 0xb0, 0xa8, 0xe0, 0xa1, 0x1d, 0xb5, 0x9a,
+	0xe7, 0xb1, 0x08, 0xf7, 0x29, 0x6e, 0x1c, 0x45, 0x76, 0x8c, 0xe3, 0x79, 0xce, 0xe3, 0xdb, 0xf0,
+	0x3b, 0xce, 0x8e, 0x8e, 0xc6, 0xf7, 0xf3, 0x94, 0xe7, 0xd3, 0x30, 0xa4, 0x2d, 0x96, 0x40, 0x0f,
+	0xc7, 0xa9, 0x8a, 0xaf, 0xcf, 0xaf, 0xc5, 0x22, 0xfc, 0x2e, 0x05, 0x47, 0x05, 0x60, 0x74, 0x95,
+	0x84, 0x48, 0xc2, 0x31, 0x19, 0xc1, 0x87, 0xd8, 0x14, 0x06, 0xe5, 0x4b, 0x82, 0x1d, 0x68, 0x4d,
+	0xe3, 0x98, 0x52, 0xa4, 0x17, 0xe1, 0x92, 0x90, 0x2c, 0x0e, 0x9b, 0x0e, 0xc9, 0x08, 0x49, 0x79,
+	0x4c, 0xa9, 0xd0, 0x8b, 0x70, 0x49, 0x41, 0x21, 0xd2, 0xd4, 0x25, 0x41, 0x2f, 0x72, 0x54, 0x10,
+	0x40, 0x3b, 0xd7, 0x5a, 0x52, 0xe0, 0x77, 0x22, 0x5a, 0xb3, 0xc7, 0xd0, 0xb5, 0xb6, 0x40, 0xae,
+	0xd6, 0x32, 0x71, 0xf9, 0x48, 0x6b, 0xba, 0x8d, 0xc7, 0x2e, 0x09, 0x71, 0x59, 0x9e, 0xd1, 0xf2,
+	0xce, 0xf8, 0x77, 0x13, 0xba, 0xd6, 0x4d, 0xb8, 0x21, 0xe7, 0x8b, 0x42, 0xe1, 0x9c, 0x2f, 0xd0,
+	0xeb, 0xa9, 0x5a, 0x3c, 0xb6, 0x49, 0x46, 0x27, 0xf5, 0x23, 0x0f, 0x41, 0xbe, 0x9a, 0x9b, 0xe7,
+	0x93, 0xcf, 0x44, 0x16, 0xdb, 0x84, 0xee, 0x47, 0x1e, 0x82, 0xf6, 0x94, 0x99, 0x51, 0x96, 0xdd,
+	0x26, 0x76, 0x05, 0xa0, 0x3a, 0x0b, 0x95, 0x67, 0xf4, 0xa4, 0x7e, 0x44, 0x6b, 0xd4, 0x41, 0x2b,
+	0x4d, 0x99, 0xdb, 0x8f, 0x70, 0x19, 0x1c, 0xc2, 0xa8, 0xba, 0xf1, 0x11, 0xf9, 0xac, 0x47, 0xdc,
+	0x65, 0x18, 0x25, 0xab, 0xbb, 0xad, 0x64, 0xdf, 0x4a, 0x2e, 0xc1, 0x98, 0x25, 0xa5, 0x1a, 0x56,
+	0x70, 0x40, 0x82, 0x4b, 0x68, 0xc0, 0x60, 0xb8, 0xe0, 0x26, 0xbe, 0x8a, 0xc4, 0x54, 0xdd, 0x88,
+	0x22, 0xaf, 0x6b, 0x18, 0x46, 0xd9, 0x84, 0xa7, 0xe9, 0x13, 0xb5, 0xc8, 0x28, 0xbd, 0xfb, 0x51,
+	0x49, 0xb3, 0x13, 0xd8, 0x3a, 0x17, 0xf9, 0x8d, 0xc8, 0x4f, 0xd4, 0x74, 0xca, 0xb3, 0x64, 0x4d,
+	0xe9, 0x0c, 0xa1, 0x17, 0x5b, 0x21, 0xe7, 0xb1, 0x82, 0x64, 0xff, 0x6c, 0xc0, 0xb6, 0x3d, 0x25,
+	0x12, 0x7a, 0xa6, 0x32, 0x2d, 0xd6, 0x1c, 0x73, 0x0a, 0x3b, 0x56, 0x16, 0x7d, 0x2a, 0xb5, 0x91,
+	0xb1, 0x0e, 0xdb, 0x94, 0x3b, 0xdf, 0xb7, 0x19, 0x59, 0x3b, 0xe8, 0xa8, 0x94, 0x8a, 0x56, 0xb6,
+	0x8d, 0x3f, 0x84, 0x41, 0x49, 0xa1, 0xaf, 0x4c, 0x55, 0xd2, 0x69, 0x8d, 0x39, 0x74, 0xc3, 0xd3,
+	0x79, 0x51, 0xc9, 0x2d, 0xc1, 0x7e, 0x02, 0x9b, 0x67, 0x32, 0xbb, 0xf4, 0x5e, 0xec, 0xea, 0x7f,
+	0xa1, 0xaa, 0x23, 0x99, 0x81, 0x6d, 0x27, 0x14, 0x89, 0x3f, 0xce, 0x85, 0x36, 0xeb, 0x1b, 0x53,
+	0xd9, 0x1c, 0x9a, 0x4b, 0xcd, 0xc1, 0xb3, 0x5c, 0xab, 0x66, 0x39, 0x54, 0x9a, 0xe7, 0x97, 0xd6,
+	0x00, 0x83, 0x88, 0xd6, 0xec, 0x0b, 0x78, 0xe0, 0x6e, 0xbd, 0x57, 0x47, 0xc4, 0x8a, 0x50, 0x88,
+	0xd3, 0xcd, 0xed, 0xa8, 0x02, 0xd8, 0x7f, 0x1b, 0x30, 0x2a, 0xdf, 0x70, 0xa7, 0x6f, 0xd6, 0x9e,
+	0x55, 0x7b, 0x62, 0xeb, 0xf5, 0x4f, 0x6c, 0xd7, 0x9f, 0xb8, 0x5f, 0xd6, 0xdd, 0x8e, 0xab, 0x7d,
+	0x44, 0xa1, 0x6f, 0x72, 0x31, 0x4b, 0x6f, 0x29, 0x93, 0x06, 0x91, 0x25, 0x30, 0x5f, 0xe3, 0x5c,
+	0x70, 0x53, 0xeb, 0x7e, 0x15, 0x82, 0xfc, 0xf9, 0x2c, 0x29, 0xf8, 0x7d, 0xcb, 0xaf, 0x10, 0xf6,
+	0xaf, 0x26, 0x0c, 0x9f, 0x90, 0x52, 0x27, 0x2a, 0x9b, 0xc8, 0xcb, 0x37, 0xf4, 0xd8, 0x1e, 0x74,
+	0xa6, 0x2a, 0x11, 0x69, 0x11, 0x38, 0x44, 0x60, 0x52, 0xce, 0x67, 0xa9, 0xe2, 0xc9, 0x69, 0x66,
+	0x44, 0x7e, 0xc3, 0x53, 0x7a, 0x6b, 0x2b, 0x5a, 0x42, 0xd1, 0x8c, 0x57, 0x82, 0xe7, 0x26, 0xe2,
+	0x46, 0xb8, 0xda, 0x51, 0x01, 0x54, 0x54, 0xe4, 0x44, 0xba, 0x0a, 0x42, 0x6b, 0x0a, 0x54, 0x99,
+	0x08, 0xe5, 0x0a, 0x87, 0x25, 0x10, 0x9d, 0x5d, 0x29, 0xa3, 0x5c, 0xee, 0x5b, 0x22, 0xf8, 0x10,
+	0xba, 0x14, 0xc7, 0x3a, 0x04, 0x2f, 0x6d, 0xfc, 0x47, 0x1f, 0xbd, 0x24, 0xfe, 0xa7, 0x99, 0xc9,
+	0x6f, 0x23, 0x27, 0x3c, 0xfe, 0x05, 0x6c, 0x7a, 0x30, 0x96, 0xb1, 0x6b, 0x71, 0xeb, 0x6c, 0x82,
+	0xcb, 0x2a, 0x59, 0x9a, 0x5e, 0xb2, 0x7c, 0xd2, 0xfc, 0xb8, 0xf1, 0xb4, 0xdd, 0xef, 0xef, 0x0c,
+	0x58, 0x0a, 0x43, 0xaa, 0x39, 0x6f, 0x97, 0x0b, 0x68, 0xc3, 0x8c, 0xc7, 0xd7, 0x99, 0x5a, 0xa4,
+	0x22, 0xb9, 0x14, 0x89, 0x2b, 0xca, 0x4b, 0x28, 0xfb, 0x14, 0x46, 0x74, 0xdb, 0xbd, 0x72, 0x20,
+	0x84, 0x1e, 0xb7, 0xc2, 0x2e, 0x6a, 0x0b, 0x92, 0xfd, 0xaf, 0x01, 0x1d, 0x5b, 0x29, 0x3d, 0x99,
+	0x46, 0x4d, 0x66, 0xad, 0xba, 0xfb, 0xd0, 0xcd, 0x05, 0xd7, 0x2a, 0x73, 0x91, 0xe0, 0xa8, 0xa5,
+	0x29, 0xad, 0xbd, 0x32, 0xa5, 0x2d, 0xcd, 0x79, 0x9d, 0xd5, 0x39, 0xcf, 0x9f, 0xe3, 0xba, 0xeb,
+	0xe6, 0xb8, 0xde, 0xf2, 0x1c, 0xc7, 0x60, 0x58, 0x33, 0xa0, 0x6d, 0x21, 0x35, 0x8c, 0x9d, 0xc2,
+	0x80, 0x9e, 0xfd, 0x4c, 0xae, 0xf5, 0x14, 0x83, 0x2e, 0x59, 0x41, 0x87, 0x4d, 0x8a, 0x25, 0xa0,
+	0x58, 0xb2, 0x6e, 0x76, 0x1c, 0x26, 0x61, 0xeb, 0x73, 0xc1, 0x53, 0x73, 0xf5, 0x76, 0x8e, 0x0f,
+	0xa0, 0x3d, 0xc9, 0xd5, 0x94, 0xec, 0xd8, 0x8a, 0x68, 0x1d, 0x6c, 0x43, 0xd3, 0x28, 0x67, 0xbd,
+	0xa6, 0x51, 0xec, 0x1f, 0x0d, 0xe8, 0xda, 0xbb, 0x50, 0xdc, 0xa0, 0xe5, 0x1a, 0x56, 0x1c, 0xd7,
+	0xf5, 0xbc, 0xb2, 0x33, 0x4a, 0x05, 0xe0, 0xe5, 0xfa, 0x56, 0x1b, 0x95, 0xca, 0x62, 0x5c, 0x29,
+	0x69, 0xdc, 0x99, 0x48, 0xee, 0x98, 0x76, 0x6c, 0xa9, 0x00, 0xbc, 0x4b, 0xcf, 0xd4, 0xb1, 0x1b,
+	0xd9, 0x69, 0x8d, 0x0e, 0x34, 0x62, 0x3a, 0x13, 0x39, 0x37, 0xf3, 0xbc, 0xf0, 0x90, 0x0f, 0x31,
+	0x0d, 0x60, 0x75, 0x7d, 0x26, 0xdf, 0xd8, 0x28, 0xef, 0xc3, 0x70, 0x2a, 0xb8, 0x9e, 0xe7, 0x62,
+	0x2a, 0x32, 0xa3, 0xc3, 0x96, 0x37, 0x44, 0x3a, 0xa3, 0xd7, 0x04, 0xd8, 0xef, 0x61, 0xf4, 0xa5,
+	0x48, 0x24, 0xbf, 0xef, 0xc7, 0xd2, 0x3a, 0x77, 0x64, 0x7c, 0x5a, 0x74, 0x46, 0x5a, 0xb3, 0x18,
+	0x06, 0x74, 0xf8, 0x67, 0x32, 0xad, 0x7f, 0xed, 0x34, 0x5e, 0xb3, 0xb9, 0x59, 0x6d, 0x2e, 0x1d,
+	0xd6, 0xf2, 0x1c, 0x86, 0x86, 0x95, 0x7f, 0x2e, 0xf2, 0x83, 0xd6, 0xec, 0x0b, 0x77, 0xc9, 0x1d,
+	0x56, 0x7b, 0x17, 0x3a, 0x13, 0x99, 0x8a, 0x22, 0x30, 0xed, 0x10, 0x5e, 0x6a, 0x17, 0x59, 0x26,
+	0xfb, 0x1a, 0x3a, 0x84, 0xad, 0x0d, 0xf1, 0x36, 0xca, 0x92, 0xae, 0xab, 0xe7, 0x10, 0x0f, 0xf5,
+	0x4c, 0xb8, 0xe1, 0xa4, 0xfb, 0x30, 0xa2, 0x35, 0xfb, 0x0a, 0x86, 0x2f, 0x95, 0x8c, 0xc5, 0x5b,
+	0x47, 0xfd, 0xca, 0xc9, 0x5f, 0xc3, 0xa6, 0x6b, 0xc9, 0x77, 0xd8, 0xe0, 0x03, 0xe8, 0xbb, 0x2e,
+	0x5a, 0x98, 0x61, 0xcf, 0x7e, 0x8b, 0xd4, 0x1b, 0x7a, 0x54, 0x4a, 0xb1, 0xa7, 0x38, 0x88, 0x69,
+	0xdc, 0x7c, 0xb7, 0xda, 0x38, 0x1a, 0x67, 0xa9, 0xcc, 0xc4, 0xf3, 0x2c, 0x2d, 0x47, 0xe7, 0x0a,
+	0x61, 0x7f, 0x69, 0x41, 0xcf, 0x1d, 0xb6, 0x36, 0x18, 0xf6, 0xa1, 0x6b, 0x77, 0xb9, 0x33, 0x1c,
+	0x85, 0xe7, 0xe7, 0x62, 0xaa, 0x8c, 0x78, 0x94, 0x24, 0xb9, 0x8b, 0x33, 0x0f, 0xa1, 0x8f, 0x15,
+	0x95, 0x65, 0x22, 0x36, 0x5e, 0x0d, 0xf5, 0x21, 0x5b, 0x22, 0xb5, 0x39, 0x17, 0x22, 0x73, 0x15,
+	0xb4, 0xa4, 0xb1, 0x8f, 0x24, 0x52, 0xfb, 0x07, 0xd8, 0x6f, 0xe9, 0x25, 0x14, 0xb5, 0x9b, 0xe4,
+	0x7c, 0x2a, 0x34, 0xd5, 0xd1, 0x6e, 0xe4, 0x28, 0xec, 0x76, 0x17, 0xb7, 0xf8, 0x85, 0xda, 0x27,
+	0xd8, 0x12, 0x56, 0x67, 0xb7, 0x5d, 0x53, 0xdb, 0xed, 0x46, 0x1e, 0x12, 0x3c, 0x84, 0xbe, 0x1b,
+	0x0e, 0x8b, 0xee, 0x3b, 0x76, 0x43, 0x2b, 0xd9, 0xe9, 0xe8, 0x4b, 0xc7, 0xb4, 0xad, 0xb7, 0x94,
+	0x1d, 0xff, 0x12, 0xb6, 0x6a, 0xac, 0xbb, 0xda, 0x6f, 0xd7, 0x6b, 0xbf, 0xec, 0xb7, 0xb0, 0xe9,
+	0xce, 0xbf, 0x23, 0x5e, 0x0e, 0xa1, 0xaf, 0xad, 0x60, 0x11, 0x2f, 0x43, 0x5f, 0xbb, 0xa8, 0xe4,
+	0xb2, 0xa7, 0xb0, 0x73, 0x3e, 0xbf, 0xd0, 0x71, 0x2e, 0x2f, 0xee, 0x11, 0xe0, 0x58, 0x3d, 0x9d,
+	0xb7, 0xed, 0xc1, 0x83, 0xa8, 0x02, 0xd8, 0xdf, 0x1a, 0xb0, 0xfd, 0x39, 0x7d, 0x67, 0xdf, 0x7e,
+	0xeb, 0x1d, 0x02, 0xad, 0x94, 0xca, 0xa9, 0x2c, 0x7e, 0xaf, 0x58, 0x02, 0x15, 0x9c, 0xe1, 0xff,
+	0x1b, 0x75, 0x2d, 0x32, 0x37, 0x4f, 0x56, 0x00, 0xfb, 0x6b, 0x03, 0x46, 0xa5, 0x82, 0x77, 0xce,
+	0xc0, 0xeb, 0x34, 0x64, 0xd0, 0xa5, 0x3f, 0x58, 0x45, 0xa1, 0xb6, 0xed, 0x92, 0xfe, 0x39, 0x45,
+	0x8e, 0x13, 0xbc, 0x0b, 0x5b, 0x99, 0xf8, 0x93, 0x39, 0x2b, 0xf5, 0xb1, 0xf3, 0x70, 0x1d, 0x64,
+	0x5f, 0x41, 0x1f, 0xc3, 0xf3, 0x1b, 0x95, 0xbd, 0xa9, 0x2e, 0x98, 0x76, 0x93, 0x89, 0x16, 0xa6,
+	0x98, 0x4c, 0x2c, 0x75, 0xfc, 0x9f, 0x3e, 0x40, 0xae, 0xe6, 0x46, 0x90, 0x5a, 0xc1, 0x4f, 0x61,
+	0xf0, 0x8c, 0x6b, 0x63, 0x89, 0x11, 0xe9, 0x5b, 0xf5, 0x8e, 0xb1, 0xf7, 0x00, 0xb6, 0x11, 0xfc,
+	0x0a, 0x46, 0x4b, 0xdf, 0x58, 0x41, 0xe0, 0x7d, 0x93, 0xb9, 0xb2, 0x33, 0xde, 0x7d, 0xc5, 0x77,
+	0x1a, 0xdb, 0x08, 0x7e, 0x06, 0x6d, 0xfc, 0xac, 0x0a, 0x76, 0xec, 0x99, 0xd5, 0x17, 0xd6, 0x78,
+	0x05, 0x61, 0x1b, 0xc1, 0x27, 0x18, 0xd4, 0x59, 0xe2, 0x80, 0x60, 0xb7, 0x5e, 0xd8, 0x28, 0x8c,
+	0xc6, 0xaf, 0xac, 0x76, 0x6c, 0x23, 0xf8, 0x35, 0x6c, 0x39, 0xd0, 0xfd, 0x17, 0xd8, 0xf7, 0x05,
+	0xbd, 0xe7, 0xbd, 0xee, 0x80, 0x63, 0x00, 0x3b, 0x2f, 0x9f, 0x66, 0x13, 0xb5, 0x6a, 0x95, 0x07,
+	0x2b, 0x13, 0x35, 0xdb, 0x08, 0xde, 0x83, 0x2e, 0xcd, 0x45, 0x3a, 0x78, 0xe0, 0x0d, 0x49, 0x4e,
+	0xd3, 0xed, 0x0a, 0xc2, 0x1c, 0x65, 0x1b, 0xc1, 0x43, 0xd8, 0x79, 0x54, 0x0d, 0x64, 0xc4, 0x09,
+	0xf6, 0x2a, 0xa9, 0x15, 0x1f, 0x10, 0x4a, 0xfb, 0xdc, 0xb4, 0xe5, 0x22, 0xd6, 0x79, 0xa0, 0x36,
+	0x81, 0x8d, 0x47, 0x1e, 0xe6, 0xee, 0x3b, 0x82, 0x01, 0xae, 0xa8, 0x65, 0xad, 0xbe, 0xc8, 0x6b,
+	0x7b, 0xa5, 0x7c, 0xff, 0x37, 0xc2, 0x89, 0xef, 0x55, 0xdc, 0x15, 0xbd, 0x08, 0x25, 0x93, 0x0d,
+	0xd0, 0x5f, 0x76, 0x83, 0xb5, 0x80, 0xdf, 0x1e, 0xc7, 0x3b, 0xbe, 0xa9, 0xdd, 0x1d, 0x1f, 0xc1,
+	0xf0, 0x84, 0xcf, 0x70, 0x58, 0x3a, 0xa3, 0x2f, 0x97, 0x15, 0xb5, 0x5e, 0xe7, 0x9f, 0xf7, 0x01,
+	0xf0, 0x88, 0xd3, 0x29, 0x16, 0xcc, 0xfb, 0xbf, 0x86, 0xe4, 0xef, 0xf5, 0x9a, 0x8f, 0x60, 0x88,
+	0x3b, 0x5d, 0x61, 0xd4, 0xc1, 0x6e, 0xad, 0x4e, 0xd6, 0x9e, 0xe4, 0x95, 0x5e, 0xd2, 0xcc, 0x7d,
+	0x5e, 0x3e, 0xb7, 0x4d, 0x6e, 0x45, 0xb7, 0x5a, 0xc5, 0x65, 0x1b, 0xc1, 0xc7, 0x30, 0x2a, 0x2b,
+	0xed, 0x99, 0xad, 0x10, 0xef, 0x58, 0x91, 0xa5, 0xfa, 0x5b, 0xcf, 0xc5, 0x0f, 0x1a, 0xc1, 0x43,
+	0xe8, 0x15, 0x31, 0x60, 0xd5, 0xab, 0x17, 0xd9, 0xf1, 0x5e, 0x1d, 0x2c, 0x8d, 0xf7, 0x1e, 0x66,
+	0x96, 0x29, 0xab, 0xcb, 0x16, 0x89, 0x15, 0xe4, 0xb8, 0x4e, 0x92, 0x63, 0xb7, 0x9f, 0x94, 0xdf,
+	0x2d, 0xdf, 0xa8, 0x57, 0xbd, 0x69, 0x79, 0xcf, 0x45, 0x97, 0xfe, 0xdc, 0xff, 0xfc, 0xff, 0x03,
+	0x00, 0x1e, 0xbb, 0x72, 0x53, 0xd0, 0x17, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// RoutePointClient is the client API for RoutePoint service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type RoutePointClient interface {
+	LastPoint(ctx context.Context, in *Identifier, opts ...grpc.CallOption) (*Point, error)
+	ServerStatistic(ctx context.Context, in *ServerCommand, opts ...grpc.CallOption) (*ServerResponse, error)
+	Ping(ctx context.Context, in *PingCommand, opts ...grpc.CallOption) (*PingCommand, error)
+	SendCommand(ctx context.Context, in *CommandRequest, opts ...grpc.CallOption) (*CommandResponse, error)
+	CommandStatus(ctx context.Context, in *CommandIdentifier, opts ...grpc.CallOption) (*CommandResponse, error)
+	DeviceInfo(ctx context.Context, in *Identifier, opts ...grpc.CallOption) (*DeviceConfig, error)
+	Alarms(ctx context.Context, in *AlarmRequest, opts ...grpc.CallOption) (*AlarmList, error)
+	AcknowledgeAlarm(ctx context.Context, in *AlarmIdentifier, opts ...grpc.CallOption) (*Alarm, error)
+	HealthHistory(ctx context.Context, in *HealthRequest, opts ...grpc.CallOption) (*HealthList, error)
+	ListVoice(ctx context.Context, in *Identifier, opts ...grpc.CallOption) (*MediaList, error)
+	GetVoice(ctx context.Context, in *MediaIdentifier, opts ...grpc.CallOption) (*Media, error)
+	SendVoice(ctx context.Context, in *VoiceRequest, opts ...grpc.CallOption) (*CommandList, error)
+	CapturePhoto(ctx context.Context, in *Identifier, opts ...grpc.CallOption) (*CommandResponse, error)
+	ListImages(ctx context.Context, in *Identifier, opts ...grpc.CallOption) (*MediaList, error)
+	GetImage(ctx context.Context, in *MediaIdentifier, opts ...grpc.CallOption) (*Media, error)
+	ListSessions(ctx context.Context, in *SessionRequest, opts ...grpc.CallOption) (*SessionList, error)
+	DeviceOnline(ctx context.Context, in *Identifier, opts ...grpc.CallOption) (*Session, error)
+	SubscribePoints(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (RoutePoint_SubscribePointsClient, error)
+	History(ctx context.Context, in *HistoryRequest, opts ...grpc.CallOption) (*HistoryResponse, error)
+	SetTimeZone(ctx context.Context, in *TimeZone, opts ...grpc.CallOption) (*TimeZone, error)
+	DeviceTimeZone(ctx context.Context, in *Identifier, opts ...grpc.CallOption) (*TimeZone, error)
+}
+
+type routePointClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewRoutePointClient(cc *grpc.ClientConn) RoutePointClient {
+	return &routePointClient{cc}
 }
 
 func (c *routePointClient) LastPoint(ctx context.Context, in *Identifier, opts ...grpc.CallOption) (*Point, error) {
@@ -425,88 +2474,618 @@ func (c *routePointClient) LastPoint(ctx context.Context, in *Identifier, opts .
 	if err != nil {
 		return nil, err
 	}
-	return out, nil
+	return out, nil
+}
+
+func (c *routePointClient) ServerStatistic(ctx context.Context, in *ServerCommand, opts ...grpc.CallOption) (*ServerResponse, error) {
+	out := new(ServerResponse)
+	err := c.cc.Invoke(ctx, "/api.routePoint/ServerStatistic", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routePointClient) Ping(ctx context.Context, in *PingCommand, opts ...grpc.CallOption) (*PingCommand, error) {
+	out := new(PingCommand)
+	err := c.cc.Invoke(ctx, "/api.routePoint/Ping", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routePointClient) SendCommand(ctx context.Context, in *CommandRequest, opts ...grpc.CallOption) (*CommandResponse, error) {
+	out := new(CommandResponse)
+	err := c.cc.Invoke(ctx, "/api.routePoint/SendCommand", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routePointClient) CommandStatus(ctx context.Context, in *CommandIdentifier, opts ...grpc.CallOption) (*CommandResponse, error) {
+	out := new(CommandResponse)
+	err := c.cc.Invoke(ctx, "/api.routePoint/CommandStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routePointClient) DeviceInfo(ctx context.Context, in *Identifier, opts ...grpc.CallOption) (*DeviceConfig, error) {
+	out := new(DeviceConfig)
+	err := c.cc.Invoke(ctx, "/api.routePoint/DeviceInfo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routePointClient) Alarms(ctx context.Context, in *AlarmRequest, opts ...grpc.CallOption) (*AlarmList, error) {
+	out := new(AlarmList)
+	err := c.cc.Invoke(ctx, "/api.routePoint/Alarms", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routePointClient) AcknowledgeAlarm(ctx context.Context, in *AlarmIdentifier, opts ...grpc.CallOption) (*Alarm, error) {
+	out := new(Alarm)
+	err := c.cc.Invoke(ctx, "/api.routePoint/AcknowledgeAlarm", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routePointClient) HealthHistory(ctx context.Context, in *HealthRequest, opts ...grpc.CallOption) (*HealthList, error) {
+	out := new(HealthList)
+	err := c.cc.Invoke(ctx, "/api.routePoint/HealthHistory", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routePointClient) ListVoice(ctx context.Context, in *Identifier, opts ...grpc.CallOption) (*MediaList, error) {
+	out := new(MediaList)
+	err := c.cc.Invoke(ctx, "/api.routePoint/ListVoice", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routePointClient) GetVoice(ctx context.Context, in *MediaIdentifier, opts ...grpc.CallOption) (*Media, error) {
+	out := new(Media)
+	err := c.cc.Invoke(ctx, "/api.routePoint/GetVoice", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routePointClient) SendVoice(ctx context.Context, in *VoiceRequest, opts ...grpc.CallOption) (*CommandList, error) {
+	out := new(CommandList)
+	err := c.cc.Invoke(ctx, "/api.routePoint/SendVoice", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routePointClient) CapturePhoto(ctx context.Context, in *Identifier, opts ...grpc.CallOption) (*CommandResponse, error) {
+	out := new(CommandResponse)
+	err := c.cc.Invoke(ctx, "/api.routePoint/CapturePhoto", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routePointClient) ListImages(ctx context.Context, in *Identifier, opts ...grpc.CallOption) (*MediaList, error) {
+	out := new(MediaList)
+	err := c.cc.Invoke(ctx, "/api.routePoint/ListImages", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routePointClient) GetImage(ctx context.Context, in *MediaIdentifier, opts ...grpc.CallOption) (*Media, error) {
+	out := new(Media)
+	err := c.cc.Invoke(ctx, "/api.routePoint/GetImage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routePointClient) ListSessions(ctx context.Context, in *SessionRequest, opts ...grpc.CallOption) (*SessionList, error) {
+	out := new(SessionList)
+	err := c.cc.Invoke(ctx, "/api.routePoint/ListSessions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routePointClient) DeviceOnline(ctx context.Context, in *Identifier, opts ...grpc.CallOption) (*Session, error) {
+	out := new(Session)
+	err := c.cc.Invoke(ctx, "/api.routePoint/DeviceOnline", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routePointClient) SubscribePoints(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (RoutePoint_SubscribePointsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_RoutePoint_serviceDesc.Streams[0], "/api.routePoint/SubscribePoints", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &routePointSubscribePointsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type RoutePoint_SubscribePointsClient interface {
+	Recv() (*Point, error)
+	grpc.ClientStream
+}
+
+type routePointSubscribePointsClient struct {
+	grpc.ClientStream
+}
+
+func (x *routePointSubscribePointsClient) Recv() (*Point, error) {
+	m := new(Point)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *routePointClient) History(ctx context.Context, in *HistoryRequest, opts ...grpc.CallOption) (*HistoryResponse, error) {
+	out := new(HistoryResponse)
+	err := c.cc.Invoke(ctx, "/api.routePoint/History", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routePointClient) SetTimeZone(ctx context.Context, in *TimeZone, opts ...grpc.CallOption) (*TimeZone, error) {
+	out := new(TimeZone)
+	err := c.cc.Invoke(ctx, "/api.routePoint/SetTimeZone", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *routePointClient) DeviceTimeZone(ctx context.Context, in *Identifier, opts ...grpc.CallOption) (*TimeZone, error) {
+	out := new(TimeZone)
+	err := c.cc.Invoke(ctx, "/api.routePoint/DeviceTimeZone", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RoutePointServer is the server API for RoutePoint service.
+type RoutePointServer interface {
+	LastPoint(context.Context, *Identifier) (*Point, error)
+	ServerStatistic(context.Context, *ServerCommand) (*ServerResponse, error)
+	Ping(context.Context, *PingCommand) (*PingCommand, error)
+	SendCommand(context.Context, *CommandRequest) (*CommandResponse, error)
+	CommandStatus(context.Context, *CommandIdentifier) (*CommandResponse, error)
+	DeviceInfo(context.Context, *Identifier) (*DeviceConfig, error)
+	Alarms(context.Context, *AlarmRequest) (*AlarmList, error)
+	AcknowledgeAlarm(context.Context, *AlarmIdentifier) (*Alarm, error)
+	HealthHistory(context.Context, *HealthRequest) (*HealthList, error)
+	ListVoice(context.Context, *Identifier) (*MediaList, error)
+	GetVoice(context.Context, *MediaIdentifier) (*Media, error)
+	SendVoice(context.Context, *VoiceRequest) (*CommandList, error)
+	CapturePhoto(context.Context, *Identifier) (*CommandResponse, error)
+	ListImages(context.Context, *Identifier) (*MediaList, error)
+	GetImage(context.Context, *MediaIdentifier) (*Media, error)
+	ListSessions(context.Context, *SessionRequest) (*SessionList, error)
+	DeviceOnline(context.Context, *Identifier) (*Session, error)
+	SubscribePoints(*SubscribeRequest, RoutePoint_SubscribePointsServer) error
+	History(context.Context, *HistoryRequest) (*HistoryResponse, error)
+	SetTimeZone(context.Context, *TimeZone) (*TimeZone, error)
+	DeviceTimeZone(context.Context, *Identifier) (*TimeZone, error)
+}
+
+func RegisterRoutePointServer(s *grpc.Server, srv RoutePointServer) {
+	s.RegisterService(&_RoutePoint_serviceDesc, srv)
+}
+
+func _RoutePoint_LastPoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Identifier)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoutePointServer).LastPoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.routePoint/LastPoint",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoutePointServer).LastPoint(ctx, req.(*Identifier))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoutePoint_ServerStatistic_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ServerCommand)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoutePointServer).ServerStatistic(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.routePoint/ServerStatistic",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoutePointServer).ServerStatistic(ctx, req.(*ServerCommand))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoutePoint_Ping_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PingCommand)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoutePointServer).Ping(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.routePoint/Ping",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoutePointServer).Ping(ctx, req.(*PingCommand))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoutePoint_SendCommand_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CommandRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoutePointServer).SendCommand(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.routePoint/SendCommand",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoutePointServer).SendCommand(ctx, req.(*CommandRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *routePointClient) ServerStatistic(ctx context.Context, in *ServerCommand, opts ...grpc.CallOption) (*ServerResponse, error) {
-	out := new(ServerResponse)
-	err := c.cc.Invoke(ctx, "/api.routePoint/ServerStatistic", in, out, opts...)
-	if err != nil {
+func _RoutePoint_CommandStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CommandIdentifier)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(RoutePointServer).CommandStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.routePoint/CommandStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoutePointServer).CommandStatus(ctx, req.(*CommandIdentifier))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func (c *routePointClient) Ping(ctx context.Context, in *PingCommand, opts ...grpc.CallOption) (*PingCommand, error) {
-	out := new(PingCommand)
-	err := c.cc.Invoke(ctx, "/api.routePoint/Ping", in, out, opts...)
-	if err != nil {
+func _RoutePoint_DeviceInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Identifier)
+	if err := dec(in); err != nil {
 		return nil, err
 	}
-	return out, nil
+	if interceptor == nil {
+		return srv.(RoutePointServer).DeviceInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.routePoint/DeviceInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoutePointServer).DeviceInfo(ctx, req.(*Identifier))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-// RoutePointServer is the server API for RoutePoint service.
-type RoutePointServer interface {
-	LastPoint(context.Context, *Identifier) (*Point, error)
-	ServerStatistic(context.Context, *ServerCommand) (*ServerResponse, error)
-	Ping(context.Context, *PingCommand) (*PingCommand, error)
+func _RoutePoint_Alarms_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AlarmRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoutePointServer).Alarms(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.routePoint/Alarms",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoutePointServer).Alarms(ctx, req.(*AlarmRequest))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func RegisterRoutePointServer(s *grpc.Server, srv RoutePointServer) {
-	s.RegisterService(&_RoutePoint_serviceDesc, srv)
+func _RoutePoint_AcknowledgeAlarm_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AlarmIdentifier)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoutePointServer).AcknowledgeAlarm(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.routePoint/AcknowledgeAlarm",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoutePointServer).AcknowledgeAlarm(ctx, req.(*AlarmIdentifier))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-func _RoutePoint_LastPoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _RoutePoint_HealthHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HealthRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoutePointServer).HealthHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.routePoint/HealthHistory",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoutePointServer).HealthHistory(ctx, req.(*HealthRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoutePoint_ListVoice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(Identifier)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(RoutePointServer).LastPoint(ctx, in)
+		return srv.(RoutePointServer).ListVoice(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/api.routePoint/LastPoint",
+		FullMethod: "/api.routePoint/ListVoice",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RoutePointServer).LastPoint(ctx, req.(*Identifier))
+		return srv.(RoutePointServer).ListVoice(ctx, req.(*Identifier))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _RoutePoint_ServerStatistic_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(ServerCommand)
+func _RoutePoint_GetVoice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MediaIdentifier)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(RoutePointServer).ServerStatistic(ctx, in)
+		return srv.(RoutePointServer).GetVoice(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/api.routePoint/ServerStatistic",
+		FullMethod: "/api.routePoint/GetVoice",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RoutePointServer).ServerStatistic(ctx, req.(*ServerCommand))
+		return srv.(RoutePointServer).GetVoice(ctx, req.(*MediaIdentifier))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _RoutePoint_Ping_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(PingCommand)
+func _RoutePoint_SendVoice_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VoiceRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(RoutePointServer).Ping(ctx, in)
+		return srv.(RoutePointServer).SendVoice(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/api.routePoint/Ping",
+		FullMethod: "/api.routePoint/SendVoice",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RoutePointServer).Ping(ctx, req.(*PingCommand))
+		return srv.(RoutePointServer).SendVoice(ctx, req.(*VoiceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoutePoint_CapturePhoto_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Identifier)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoutePointServer).CapturePhoto(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.routePoint/CapturePhoto",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoutePointServer).CapturePhoto(ctx, req.(*Identifier))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoutePoint_ListImages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Identifier)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoutePointServer).ListImages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.routePoint/ListImages",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoutePointServer).ListImages(ctx, req.(*Identifier))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoutePoint_GetImage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MediaIdentifier)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoutePointServer).GetImage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.routePoint/GetImage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoutePointServer).GetImage(ctx, req.(*MediaIdentifier))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoutePoint_ListSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoutePointServer).ListSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.routePoint/ListSessions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoutePointServer).ListSessions(ctx, req.(*SessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoutePoint_DeviceOnline_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Identifier)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoutePointServer).DeviceOnline(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.routePoint/DeviceOnline",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoutePointServer).DeviceOnline(ctx, req.(*Identifier))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoutePoint_SubscribePoints_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RoutePointServer).SubscribePoints(m, &routePointSubscribePointsServer{stream})
+}
+
+type RoutePoint_SubscribePointsServer interface {
+	Send(*Point) error
+	grpc.ServerStream
+}
+
+type routePointSubscribePointsServer struct {
+	grpc.ServerStream
+}
+
+func (x *routePointSubscribePointsServer) Send(m *Point) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _RoutePoint_History_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoutePointServer).History(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.routePoint/History",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoutePointServer).History(ctx, req.(*HistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoutePoint_SetTimeZone_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TimeZone)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoutePointServer).SetTimeZone(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.routePoint/SetTimeZone",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoutePointServer).SetTimeZone(ctx, req.(*TimeZone))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoutePoint_DeviceTimeZone_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Identifier)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoutePointServer).DeviceTimeZone(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/api.routePoint/DeviceTimeZone",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoutePointServer).DeviceTimeZone(ctx, req.(*Identifier))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -527,7 +3106,81 @@ var _RoutePoint_serviceDesc = grpc.ServiceDesc{
 			MethodName: "Ping",
 			Handler:    _RoutePoint_Ping_Handler,
 		},
+		{
+			MethodName: "SendCommand",
+			Handler:    _RoutePoint_SendCommand_Handler,
+		},
+		{
+			MethodName: "CommandStatus",
+			Handler:    _RoutePoint_CommandStatus_Handler,
+		},
+		{
+			MethodName: "DeviceInfo",
+			Handler:    _RoutePoint_DeviceInfo_Handler,
+		},
+		{
+			MethodName: "Alarms",
+			Handler:    _RoutePoint_Alarms_Handler,
+		},
+		{
+			MethodName: "AcknowledgeAlarm",
+			Handler:    _RoutePoint_AcknowledgeAlarm_Handler,
+		},
+		{
+			MethodName: "HealthHistory",
+			Handler:    _RoutePoint_HealthHistory_Handler,
+		},
+		{
+			MethodName: "ListVoice",
+			Handler:    _RoutePoint_ListVoice_Handler,
+		},
+		{
+			MethodName: "GetVoice",
+			Handler:    _RoutePoint_GetVoice_Handler,
+		},
+		{
+			MethodName: "SendVoice",
+			Handler:    _RoutePoint_SendVoice_Handler,
+		},
+		{
+			MethodName: "CapturePhoto",
+			Handler:    _RoutePoint_CapturePhoto_Handler,
+		},
+		{
+			MethodName: "ListImages",
+			Handler:    _RoutePoint_ListImages_Handler,
+		},
+		{
+			MethodName: "GetImage",
+			Handler:    _RoutePoint_GetImage_Handler,
+		},
+		{
+			MethodName: "ListSessions",
+			Handler:    _RoutePoint_ListSessions_Handler,
+		},
+		{
+			MethodName: "DeviceOnline",
+			Handler:    _RoutePoint_DeviceOnline_Handler,
+		},
+		{
+			MethodName: "History",
+			Handler:    _RoutePoint_History_Handler,
+		},
+		{
+			MethodName: "SetTimeZone",
+			Handler:    _RoutePoint_SetTimeZone_Handler,
+		},
+		{
+			MethodName: "DeviceTimeZone",
+			Handler:    _RoutePoint_DeviceTimeZone_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "SubscribePoints",
+			Handler:       _RoutePoint_SubscribePoints_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "point_service.proto",
 }
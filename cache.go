@@ -9,6 +9,7 @@ import (
 type Cache struct {
 	mu          *sync.RWMutex
 	Items       map[string]Item
+	evicted     uint64
 	stopCleaner chan struct{}
 }
 
@@ -101,11 +102,26 @@ func (c *Cache) DeleteExpired() {
 	for k, item := range c.Items {
 		if timeNow > item.Expiration {
 			delete(c.Items, k)
+			c.evicted++
 		}
 	}
 	c.mu.Unlock()
 }
 
+// Len returns the number of items, including expired ones not yet removed.
+func (c *Cache) Len() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.Items)
+}
+
+// Evicted returns the number of expired items removed by the cleaner.
+func (c *Cache) Evicted() uint64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.evicted
+}
+
 func runCleaner(c *Cache) {
 	ticker := time.NewTicker(DefaultCleanupInterval)
 	for {
@@ -0,0 +1,249 @@
+package main
+
+import (
+	"Q50RT/q50"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Command states
+const (
+	CommandQueued = "queued"
+	CommandSent   = "sent"
+	CommandDone   = "done"
+	CommandFailed = "failed"
+)
+
+const (
+	// commandExpiration is how long a command stays queryable.
+	commandExpiration = 24 * time.Hour
+
+	// commandReplyTimeout is how long a sent command waits for the reply.
+	commandReplyTimeout = time.Minute
+)
+
+type Command struct {
+	ID       uint64
+	DeviceID string
+	Name     string
+	Args     []string
+	Status   string
+	Reply    string
+	Created  time.Time
+	Updated  time.Time
+	done     chan struct{}
+}
+
+// CommandQueue holds the downlink commands per device. Queued commands
+// are sent when the device is online, sent commands wait for the reply
+// frame of the same type.
+type CommandQueue struct {
+	mu       *sync.Mutex
+	lastID   uint64
+	queued   map[string][]*Command
+	sent     map[string][]*Command
+	flushing map[string]bool
+	commands *Cache
+}
+
+func NewCommandQueue() *CommandQueue {
+	return &CommandQueue{
+		mu:       &sync.Mutex{},
+		queued:   make(map[string][]*Command),
+		sent:     make(map[string][]*Command),
+		flushing: make(map[string]bool),
+		commands: NewCache(),
+	}
+}
+
+func (q *CommandQueue) Push(deviceID, name string, args []string) *Command {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.lastID++
+	now := time.Now()
+	cmd := &Command{
+		ID:       q.lastID,
+		DeviceID: deviceID,
+		Name:     name,
+		Args:     args,
+		Status:   CommandQueued,
+		Created:  now,
+		Updated:  now,
+		done:     make(chan struct{}),
+	}
+
+	q.queued[deviceID] = append(q.queued[deviceID], cmd)
+	q.commands.SetExp(commandKey(cmd.ID), cmd, commandExpiration)
+	return cmd
+}
+
+// Flush sends the queued commands of a device in order. A command that
+// can't be sent stays queued with the ones after it. Sent commands that
+// got no reply in time are failed. The queue is not locked while a command
+// is sent; a flush of a device that is already flushing returns at once,
+// the running one sends the commands queued meanwhile.
+func (q *CommandQueue) Flush(deviceID string, send func(cmd *Command) error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.expire(deviceID)
+
+	if q.flushing[deviceID] {
+		return
+	}
+	q.flushing[deviceID] = true
+	defer delete(q.flushing, deviceID)
+
+	for len(q.queued[deviceID]) > 0 {
+		// the command is sent before it is written, so that a fast reply
+		// finds it
+		cmd := q.popQueued(deviceID)
+		cmd.Status = CommandSent
+		cmd.Updated = time.Now()
+		q.sent[deviceID] = append(q.sent[deviceID], cmd)
+
+		q.mu.Unlock()
+		err := send(cmd)
+		q.mu.Lock()
+
+		if err != nil {
+			q.unsend(cmd)
+			break
+		}
+	}
+}
+
+func (q *CommandQueue) popQueued(deviceID string) *Command {
+	queued := q.queued[deviceID]
+	cmd := queued[0]
+	if len(queued) == 1 {
+		delete(q.queued, deviceID)
+	} else {
+		q.queued[deviceID] = queued[1:]
+	}
+	return cmd
+}
+
+// unsend queues a command that couldn't be written again, ahead of the
+// ones queued after it.
+func (q *CommandQueue) unsend(cmd *Command) {
+	if cmd.Status != CommandSent {
+		return
+	}
+
+	q.removeSent(cmd)
+	cmd.Status = CommandQueued
+	cmd.Updated = time.Now()
+	q.queued[cmd.DeviceID] = append([]*Command{cmd}, q.queued[cmd.DeviceID]...)
+}
+
+func (q *CommandQueue) removeSent(cmd *Command) {
+	var sent []*Command
+	for _, c := range q.sent[cmd.DeviceID] {
+		if c != cmd {
+			sent = append(sent, c)
+		}
+	}
+
+	if len(sent) == 0 {
+		delete(q.sent, cmd.DeviceID)
+	} else {
+		q.sent[cmd.DeviceID] = sent
+	}
+}
+
+// Acknowledge completes the oldest sent command of the device with the
+// given name and returns a copy of it. It reports whether the frame was a
+// command reply.
+func (q *CommandQueue) Acknowledge(deviceID, name, reply string) (Command, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	sent := q.sent[deviceID]
+	for i, cmd := range sent {
+		if cmd.Name != name {
+			continue
+		}
+
+		cmd.Status = CommandDone
+		cmd.Reply = reply
+		cmd.Updated = time.Now()
+		close(cmd.done)
+
+		sent = append(sent[:i:i], sent[i+1:]...)
+		if len(sent) == 0 {
+			delete(q.sent, deviceID)
+		} else {
+			q.sent[deviceID] = sent
+		}
+		return *cmd, true
+	}
+	return Command{}, false
+}
+
+func (q *CommandQueue) expire(deviceID string) {
+	var sent []*Command
+	for _, cmd := range q.sent[deviceID] {
+		if time.Since(cmd.Updated) < commandReplyTimeout {
+			sent = append(sent, cmd)
+			continue
+		}
+
+		cmd.Status = CommandFailed
+		cmd.Updated = time.Now()
+		close(cmd.done)
+	}
+
+	if len(sent) == 0 {
+		delete(q.sent, deviceID)
+	} else {
+		q.sent[deviceID] = sent
+	}
+}
+
+// Get returns a copy of the command with the given id. A sent command
+// whose reply is overdue is failed first, also when its device is gone.
+func (q *CommandQueue) Get(id uint64) (Command, bool) {
+	v, ok := q.commands.Get(commandKey(id))
+	if !ok {
+		return Command{}, false
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	cmd := v.(*Command)
+	q.expire(cmd.DeviceID)
+	return *cmd, true
+}
+
+// Done returns a channel that is closed when the command is acknowledged.
+func (cmd *Command) Done() <-chan struct{} {
+	return cmd.done
+}
+
+// Frame returns the downlink command as it is framed for the watch.
+func (cmd *Command) Frame() q50.Command {
+	return q50.Command{Name: cmd.Name, Args: cmd.Args}
+}
+
+// Content returns the frame content of the command, e.g. UPLOAD,600.
+func (cmd *Command) Content() string {
+	return string(cmd.Frame().Content())
+}
+
+// Description returns the command for display. The binary voice data is
+// replaced by its size.
+func (cmd *Command) Description() string {
+	if cmd.Name == q50.TK && len(cmd.Args) == 1 {
+		return fmt.Sprintf("%s,%d bytes", cmd.Name, len(cmd.Args[0]))
+	}
+	return cmd.Content()
+}
+
+func commandKey(id uint64) string {
+	return strconv.FormatUint(id, 10)
+}
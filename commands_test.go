@@ -0,0 +1,114 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestCommandQueue(t *testing.T) {
+	queue := NewCommandQueue()
+
+	upload := queue.Push("1234567890", "UPLOAD", []string{"600"})
+	find := queue.Push("1234567890", "FIND", nil)
+
+	if upload.Content() != "UPLOAD,600" || find.Content() != "FIND" {
+		t.Error("broken command content", upload.Content(), find.Content())
+	}
+
+	var sent []string
+	queue.Flush("1234567890", func(cmd *Command) error {
+		if cmd.Name == "FIND" {
+			return errors.New("offline")
+		}
+		sent = append(sent, cmd.Content())
+		return nil
+	})
+
+	if len(sent) != 1 {
+		t.Fatal("commands sent", sent)
+	}
+
+	cmd, _ := queue.Get(find.ID)
+	if cmd.Status != CommandQueued {
+		t.Error("unsent command status", cmd.Status)
+	}
+
+	if _, ok := queue.Acknowledge("1234567890", "FIND", "FIND"); ok {
+		t.Error("queued command acknowledged")
+	}
+
+	if acked, ok := queue.Acknowledge("1234567890", "UPLOAD", "UPLOAD"); !ok || acked.ID != upload.ID {
+		t.Error("sent command not acknowledged")
+	}
+
+	select {
+	case <-upload.Done():
+	default:
+		t.Error("acknowledged command is not done")
+	}
+
+	cmd, _ = queue.Get(upload.ID)
+	if cmd.Status != CommandDone || cmd.Reply != "UPLOAD" {
+		t.Error("broken acknowledged command", cmd.Status, cmd.Reply)
+	}
+}
+
+func TestCommandQueueSendUnlocked(t *testing.T) {
+	queue := NewCommandQueue()
+	find := queue.Push("1234567890", "FIND", nil)
+
+	writing := make(chan struct{})
+	release := make(chan struct{})
+	flushed := make(chan struct{})
+	go func() {
+		queue.Flush("1234567890", func(cmd *Command) error {
+			close(writing)
+			<-release
+			return nil
+		})
+		close(flushed)
+	}()
+	<-writing
+
+	// a stalled write blocks neither other devices nor the reply
+	queue.Push("9876543210", "FIND", nil)
+	queue.Flush("1234567890", func(cmd *Command) error {
+		t.Error("command sent twice")
+		return nil
+	})
+	if _, ok := queue.Acknowledge("1234567890", "FIND", "FIND"); !ok {
+		t.Error("command being written is not acknowledged")
+	}
+
+	close(release)
+	<-flushed
+
+	cmd, _ := queue.Get(find.ID)
+	if cmd.Status != CommandDone {
+		t.Error("broken command status", cmd.Status)
+	}
+}
+
+func TestCommandQueueExpire(t *testing.T) {
+	queue := NewCommandQueue()
+	upload := queue.Push("1234567890", "UPLOAD", []string{"600"})
+	queue.Flush("1234567890", func(cmd *Command) error {
+		return nil
+	})
+
+	// the watch went offline and doesn't flush its commands again
+	queue.mu.Lock()
+	upload.Updated = upload.Updated.Add(-2 * commandReplyTimeout)
+	queue.mu.Unlock()
+
+	cmd, _ := queue.Get(upload.ID)
+	if cmd.Status != CommandFailed {
+		t.Error("overdue command is not failed", cmd.Status)
+	}
+
+	select {
+	case <-upload.Done():
+	default:
+		t.Error("failed command is not done")
+	}
+}
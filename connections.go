@@ -0,0 +1,240 @@
+package main
+
+import (
+	"Q50RT/q50"
+	"errors"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/avkspog/brts"
+)
+
+var ErrDeviceOffline = errors.New("device is offline")
+
+// defaultVendor is used for devices that haven't sent a frame yet.
+const defaultVendor = "3G"
+
+// Session describes the connection of a device. The session of a device
+// that went offline is kept until it connects again.
+type Session struct {
+	DeviceID     string
+	RemoteAddr   string
+	Online       bool
+	Connected    time.Time
+	LastSeen     time.Time
+	Disconnected time.Time
+	Frames       uint64
+	Bytes        uint64
+	Reconnects   uint64
+	Types        map[string]uint64
+}
+
+type connection struct {
+	client  *brts.Client
+	mu      *sync.Mutex
+	id      string
+	session Session
+}
+
+// Connections ties the live TCP clients to the watch IDs they send.
+// A client is bound to a device on its first valid frame.
+type Connections struct {
+	mu      *sync.RWMutex
+	clients map[*brts.Client]*connection
+	devices map[string]*connection
+	vendors map[string]string
+	offline map[string]Session
+}
+
+func NewConnections() *Connections {
+	return &Connections{
+		mu:      &sync.RWMutex{},
+		clients: make(map[*brts.Client]*connection),
+		devices: make(map[string]*connection),
+		vendors: make(map[string]string),
+		offline: make(map[string]Session),
+	}
+}
+
+func (c *Connections) Add(client *brts.Client) {
+	now := time.Now()
+	conn := &connection{
+		client: client,
+		mu:     &sync.Mutex{},
+		session: Session{
+			RemoteAddr: client.Conn.RemoteAddr().String(),
+			Online:     true,
+			Connected:  now,
+			LastSeen:   now,
+			Types:      make(map[string]uint64),
+		},
+	}
+
+	c.mu.Lock()
+	c.clients[client] = conn
+	c.mu.Unlock()
+}
+
+// Bind makes client the connection of device id. A watch that reconnects
+// replaces its previous connection, which is closed if it still lingers.
+func (c *Connections) Bind(client *brts.Client, id, vendor string) {
+	c.mu.Lock()
+
+	conn, ok := c.clients[client]
+	if !ok || conn.id == id {
+		c.mu.Unlock()
+		return
+	}
+
+	prev, online := c.devices[id]
+	if online && prev != conn {
+		conn.session.Reconnects = prev.session.Reconnects + 1
+	} else if last, ok := c.offline[id]; ok {
+		conn.session.Reconnects = last.Reconnects + 1
+		delete(c.offline, id)
+	}
+
+	if len(conn.id) != 0 && c.devices[conn.id] == conn {
+		delete(c.devices, conn.id)
+	}
+	conn.id = id
+	conn.session.DeviceID = id
+	c.devices[id] = conn
+	c.vendors[id] = vendor
+	c.mu.Unlock()
+
+	if online && prev != conn {
+		log.Printf("%s: reconnected from %s, closing connection from %s", id, conn.session.RemoteAddr,
+			prev.session.RemoteAddr)
+		_ = prev.client.Conn.Close()
+	}
+}
+
+// Seen records a frame received from client.
+func (c *Connections) Seen(client *brts.Client, msgType string, size int) {
+	c.mu.Lock()
+	if conn, ok := c.clients[client]; ok {
+		conn.session.LastSeen = time.Now()
+		conn.session.Frames++
+		conn.session.Bytes += uint64(size)
+		conn.session.Types[q50.KnownType(msgType)]++
+	}
+	c.mu.Unlock()
+}
+
+func (c *Connections) Remove(client *brts.Client) {
+	c.mu.Lock()
+	conn, ok := c.clients[client]
+	if ok {
+		delete(c.clients, client)
+		if len(conn.id) != 0 && c.devices[conn.id] == conn {
+			delete(c.devices, conn.id)
+
+			session := conn.session.copy()
+			session.Online = false
+			session.Disconnected = time.Now()
+			c.offline[conn.id] = session
+		}
+	}
+	c.mu.Unlock()
+}
+
+// Count returns the number of open connections and of the devices bound
+// to one.
+func (c *Connections) Count() (clients, devices int) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return len(c.clients), len(c.devices)
+}
+
+// Session returns the current or the last session of device id.
+func (c *Connections) Session(id string) (Session, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if conn, ok := c.devices[id]; ok {
+		return conn.session.copy(), true
+	}
+	session, ok := c.offline[id]
+	return session, ok
+}
+
+// Sessions returns the sessions of all known devices ordered by device id.
+// Clients that haven't sent a valid frame yet are not listed.
+func (c *Connections) Sessions(onlineOnly bool) []Session {
+	c.mu.RLock()
+	sessions := make([]Session, 0, len(c.devices))
+	for _, conn := range c.devices {
+		sessions = append(sessions, conn.session.copy())
+	}
+	if !onlineOnly {
+		for _, session := range c.offline {
+			sessions = append(sessions, session)
+		}
+	}
+	c.mu.RUnlock()
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].DeviceID < sessions[j].DeviceID
+	})
+	return sessions
+}
+
+func (c *Connections) Online(id string) bool {
+	c.mu.RLock()
+	_, ok := c.devices[id]
+	c.mu.RUnlock()
+	return ok
+}
+
+// Vendor returns the frame prefix the device uses.
+func (c *Connections) Vendor(id string) string {
+	c.mu.RLock()
+	vendor, ok := c.vendors[id]
+	c.mu.RUnlock()
+	if !ok {
+		return defaultVendor
+	}
+	return vendor
+}
+
+// Write sends data on the client connection. Writes to one connection
+// are serialized.
+func (c *Connections) Write(client *brts.Client, data []byte) error {
+	c.mu.RLock()
+	conn, ok := c.clients[client]
+	c.mu.RUnlock()
+	if !ok {
+		return ErrDeviceOffline
+	}
+	return conn.write(data)
+}
+
+// Send writes data on the connection of device id.
+func (c *Connections) Send(id string, data []byte) error {
+	c.mu.RLock()
+	conn, ok := c.devices[id]
+	c.mu.RUnlock()
+	if !ok {
+		return ErrDeviceOffline
+	}
+	return conn.write(data)
+}
+
+func (conn *connection) write(data []byte) error {
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+	_, err := conn.client.Conn.Write(data)
+	return err
+}
+
+func (s Session) copy() Session {
+	types := make(map[string]uint64, len(s.Types))
+	for k, v := range s.Types {
+		types[k] = v
+	}
+	s.Types = types
+	return s
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"Q50RT/q50"
+	"net"
+	"testing"
+
+	"github.com/avkspog/brts"
+)
+
+func TestConnectionsSessions(t *testing.T) {
+	connections := NewConnections()
+
+	first, firstPeer := net.Pipe()
+	defer firstPeer.Close()
+	old := &brts.Client{Conn: first}
+	connections.Add(old)
+	connections.Bind(old, "1234567890", "3G")
+	connections.Seen(old, "LK", 24)
+	connections.Seen(old, "UD", 180)
+	connections.Seen(old, "X1", 20)
+	connections.Seen(old, "X2", 20)
+
+	session, ok := connections.Session("1234567890")
+	if !ok || !session.Online || session.Frames != 4 || session.Bytes != 244 || session.Types["UD"] != 1 ||
+		session.Types[q50.OtherType] != 2 || len(session.Types) != 3 {
+		t.Fatal("broken session", session)
+	}
+
+	// the watch reconnects while the old socket lingers
+	second, secondPeer := net.Pipe()
+	defer secondPeer.Close()
+	client := &brts.Client{Conn: second}
+	connections.Add(client)
+	connections.Bind(client, "1234567890", "3G")
+
+	if _, err := first.Write([]byte("x")); err == nil {
+		t.Error("lingering connection is not closed")
+	}
+
+	connections.Remove(old)
+	if !connections.Online("1234567890") {
+		t.Error("removing the old connection took the device offline")
+	}
+
+	session, _ = connections.Session("1234567890")
+	if session.Reconnects != 1 || session.Frames != 0 {
+		t.Error("broken session after reconnect", session)
+	}
+
+	connections.Remove(client)
+	session, ok = connections.Session("1234567890")
+	if !ok || session.Online || session.Disconnected.IsZero() {
+		t.Error("broken offline session", session)
+	}
+
+	if len(connections.Sessions(true)) != 0 || len(connections.Sessions(false)) != 1 {
+		t.Error("broken session list")
+	}
+}
@@ -0,0 +1,175 @@
+package geo
+
+import (
+	"Q50RT/q50"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Location is a position resolved from radio measurements.
+// Accuracy is the estimated radius in meters.
+type Location struct {
+	Latitude  float64
+	Longitude float64
+	Accuracy  float64
+}
+
+type cellKey struct {
+	mcc    uint16
+	mnc    uint16
+	lac    uint32
+	cellID uint32
+}
+
+type cell struct {
+	latitude  float64
+	longitude float64
+	rng       float64
+}
+
+// CellDB is an offline cell tower database in the OpenCellID CSV layout:
+// radio,mcc,net,area,cell,unit,lon,lat,range,...
+type CellDB struct {
+	cells map[cellKey]cell
+}
+
+// defaultCellRange is used for towers imported without a range.
+const defaultCellRange = 1000
+
+func LoadCellDB(fileName string) (*CellDB, error) {
+	f, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	return ReadCellDB(f)
+}
+
+func ReadCellDB(r io.Reader) (*CellDB, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+
+	db := &CellDB{cells: make(map[cellKey]cell)}
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if len(record) < 9 {
+			return nil, fmt.Errorf("cell db line %d: expected at least 9 fields", line)
+		}
+
+		// header line of an OpenCellID export
+		if line == 1 && strings.EqualFold(record[0], "radio") {
+			continue
+		}
+
+		key, c, err := parseCell(record)
+		if err != nil {
+			return nil, fmt.Errorf("cell db line %d: %v", line, err)
+		}
+		db.cells[key] = c
+	}
+
+	return db, nil
+}
+
+func parseCell(record []string) (cellKey, cell, error) {
+	mcc, err1 := strconv.ParseUint(record[1], 10, 16)
+	mnc, err2 := strconv.ParseUint(record[2], 10, 16)
+	lac, err3 := strconv.ParseUint(record[3], 10, 32)
+	cid, err4 := strconv.ParseUint(record[4], 10, 32)
+	lon, err5 := strconv.ParseFloat(record[6], 64)
+	lat, err6 := strconv.ParseFloat(record[7], 64)
+	for _, err := range []error{err1, err2, err3, err4, err5, err6} {
+		if err != nil {
+			return cellKey{}, cell{}, err
+		}
+	}
+
+	rng, err := strconv.ParseFloat(record[8], 64)
+	if err != nil || rng <= 0 {
+		rng = defaultCellRange
+	}
+
+	key := cellKey{mcc: uint16(mcc), mnc: uint16(mnc), lac: uint32(lac), cellID: uint32(cid)}
+	return key, cell{latitude: lat, longitude: lon, rng: rng}, nil
+}
+
+// Len returns the number of towers in the database.
+func (db *CellDB) Len() int {
+	return len(db.cells)
+}
+
+// Locate returns the signal weighted position of the known towers.
+func (db *CellDB) Locate(towers []q50.CellTower) (Location, error) {
+	var points []weightedPoint
+	for _, t := range towers {
+		c, ok := db.cells[cellKey{mcc: t.MCC, mnc: t.MNC, lac: t.LAC, cellID: t.CellID}]
+		if !ok {
+			continue
+		}
+		points = append(points, weightedPoint{
+			latitude:  c.latitude,
+			longitude: c.longitude,
+			accuracy:  c.rng,
+			weight:    signalWeight(t.RSSI),
+		})
+	}
+
+	if len(points) == 0 {
+		return Location{}, errors.New("no known cell towers")
+	}
+
+	return weightedLocation(points), nil
+}
+
+type weightedPoint struct {
+	latitude  float64
+	longitude float64
+	accuracy  float64
+	weight    float64
+}
+
+// weightedLocation averages points by weight. The accuracy is the weighted
+// mean of the point accuracies.
+func weightedLocation(points []weightedPoint) Location {
+	var lat, lon, acc, sum float64
+	for _, p := range points {
+		lat += p.latitude * p.weight
+		lon += p.longitude * p.weight
+		acc += p.accuracy * p.weight
+		sum += p.weight
+	}
+
+	return Location{
+		Latitude:  lat / sum,
+		Longitude: lon / sum,
+		Accuracy:  acc / sum,
+	}
+}
+
+// signalWeight turns a signal level into a positive weight. The watch
+// reports cell signal as a positive level and WiFi signal in dBm.
+func signalWeight(rssi int32) float64 {
+	if rssi < 0 {
+		rssi += 120
+	}
+	if rssi <= 0 {
+		return 1
+	}
+	return float64(rssi)
+}
@@ -0,0 +1,42 @@
+package geo
+
+import (
+	"Q50RT/q50"
+	"math"
+	"strings"
+	"testing"
+)
+
+const testCellDB = `radio,mcc,net,area,cell,unit,lon,lat,range,samples,changeable,created,updated,averageSignal
+GSM,250,1,46612,6762,,37.6000,55.7000,500,10,1,1459692000,1459692000,0
+GSM,250,1,46612,6761,,37.6200,55.7200,1500,10,1,1459692000,1459692000,0
+GSM,250,1,46612,1562,,37.6400,55.7400,,10,1,1459692000,1459692000,0
+`
+
+func TestCellDB(t *testing.T) {
+	db, err := ReadCellDB(strings.NewReader(testCellDB))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if db.Len() != 3 {
+		t.Fatal("cell db size", db.Len())
+	}
+
+	location, err := db.Locate([]q50.CellTower{
+		{MCC: 250, MNC: 1, LAC: 46612, CellID: 6762, RSSI: 100},
+		{MCC: 250, MNC: 1, LAC: 46612, CellID: 6761, RSSI: 100},
+		{MCC: 250, MNC: 1, LAC: 46612, CellID: 9999, RSSI: 100},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if math.Abs(location.Latitude-55.71) > 1e-9 || math.Abs(location.Longitude-37.61) > 1e-9 || location.Accuracy != 1000 {
+		t.Error("broken weighted location", location)
+	}
+
+	if _, err := db.Locate([]q50.CellTower{{MCC: 1, MNC: 1, LAC: 1, CellID: 1}}); err == nil {
+		t.Error("unknown towers located")
+	}
+}
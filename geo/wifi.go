@@ -0,0 +1,219 @@
+package geo
+
+import (
+	"Q50RT/q50"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// defaultWifiRange is the accuracy of an access point learned from GPS fixes.
+const defaultWifiRange = 50
+
+type accessPoint struct {
+	latitude  float64
+	longitude float64
+	rng       float64
+	samples   uint32
+}
+
+// WifiDB maps access point BSSIDs to locations. It is imported from CSV
+// lines of bssid,lat,lon,range and learns from positions where the watch
+// also had a valid GPS fix.
+type WifiDB struct {
+	mu    *sync.RWMutex
+	aps   map[string]accessPoint
+	dirty bool
+}
+
+func NewWifiDB() *WifiDB {
+	return &WifiDB{
+		mu:  &sync.RWMutex{},
+		aps: make(map[string]accessPoint),
+	}
+}
+
+func LoadWifiDB(fileName string) (*WifiDB, error) {
+	f, err := os.Open(fileName)
+	if os.IsNotExist(err) {
+		return NewWifiDB(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	return ReadWifiDB(f)
+}
+
+func ReadWifiDB(r io.Reader) (*WifiDB, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+
+	db := NewWifiDB()
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if len(record) < 3 {
+			return nil, fmt.Errorf("wifi db line %d: expected at least 3 fields", line)
+		}
+
+		if line == 1 && strings.EqualFold(record[0], "bssid") {
+			continue
+		}
+
+		lat, err1 := strconv.ParseFloat(record[1], 64)
+		lon, err2 := strconv.ParseFloat(record[2], 64)
+		if err1 != nil || err2 != nil {
+			return nil, fmt.Errorf("wifi db line %d: bad coordinates", line)
+		}
+
+		ap := accessPoint{latitude: lat, longitude: lon, rng: defaultWifiRange, samples: 1}
+		if len(record) > 3 {
+			if rng, err := strconv.ParseFloat(record[3], 64); err == nil && rng > 0 {
+				ap.rng = rng
+			}
+		}
+		if len(record) > 4 {
+			if samples, err := strconv.ParseUint(record[4], 10, 32); err == nil && samples > 0 {
+				ap.samples = uint32(samples)
+			}
+		}
+		db.aps[normalizeMAC(record[0])] = ap
+	}
+
+	return db, nil
+}
+
+// Save writes the database to fileName if it changed since the last save.
+func (db *WifiDB) Save(fileName string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if !db.dirty {
+		return nil
+	}
+
+	tmpName := fileName + ".tmp"
+	f, err := os.Create(tmpName)
+	if err != nil {
+		return err
+	}
+
+	if err := db.write(f); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmpName, fileName); err != nil {
+		return err
+	}
+
+	db.dirty = false
+	return nil
+}
+
+func (db *WifiDB) write(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"bssid", "lat", "lon", "range", "samples"}); err != nil {
+		return err
+	}
+
+	for mac, ap := range db.aps {
+		record := []string{
+			mac,
+			strconv.FormatFloat(ap.latitude, 'f', 7, 64),
+			strconv.FormatFloat(ap.longitude, 'f', 7, 64),
+			strconv.FormatFloat(ap.rng, 'f', 0, 64),
+			strconv.FormatUint(uint64(ap.samples), 10),
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// Len returns the number of access points in the database.
+func (db *WifiDB) Len() int {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	return len(db.aps)
+}
+
+// Locate returns the signal weighted position of the known access points.
+func (db *WifiDB) Locate(aps []q50.WifiAP) (Location, error) {
+	db.mu.RLock()
+	var points []weightedPoint
+	for _, a := range aps {
+		ap, ok := db.aps[normalizeMAC(a.MAC)]
+		if !ok {
+			continue
+		}
+		points = append(points, weightedPoint{
+			latitude:  ap.latitude,
+			longitude: ap.longitude,
+			accuracy:  ap.rng,
+			weight:    signalWeight(a.RSSI),
+		})
+	}
+	db.mu.RUnlock()
+
+	if len(points) == 0 {
+		return Location{}, errors.New("no known wifi access points")
+	}
+
+	return weightedLocation(points), nil
+}
+
+// Learn moves the access points towards a GPS position seen together with
+// them, keeping a running average of every sample.
+func (db *WifiDB) Learn(aps []q50.WifiAP, latitude, longitude float64) {
+	if len(aps) == 0 {
+		return
+	}
+
+	db.mu.Lock()
+	for _, a := range aps {
+		mac := normalizeMAC(a.MAC)
+		if len(mac) == 0 {
+			continue
+		}
+
+		ap, ok := db.aps[mac]
+		if !ok {
+			ap = accessPoint{rng: defaultWifiRange}
+		}
+
+		ap.samples++
+		n := float64(ap.samples)
+		ap.latitude += (latitude - ap.latitude) / n
+		ap.longitude += (longitude - ap.longitude) / n
+		db.aps[mac] = ap
+	}
+	db.dirty = true
+	db.mu.Unlock()
+}
+
+func normalizeMAC(mac string) string {
+	return strings.ToLower(strings.Trim(mac, " "))
+}
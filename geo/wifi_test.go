@@ -0,0 +1,56 @@
+package geo
+
+import (
+	"Q50RT/q50"
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestWifiDB(t *testing.T) {
+	db, err := ReadWifiDB(strings.NewReader("bssid,lat,lon,range\nA4:5E:60:E1:7D:29,55.7000,37.6000,30\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	aps := []q50.WifiAP{
+		{SSID: "home", MAC: "a4:5e:60:e1:7d:29", RSSI: -45},
+		{SSID: "office", MAC: "1c:3b:f3:2e:ac:11", RSSI: -71},
+	}
+
+	location, err := db.Locate(aps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if location.Latitude != 55.7 || location.Longitude != 37.6 || location.Accuracy != 30 {
+		t.Error("broken wifi location", location)
+	}
+
+	db.Learn(aps[1:], 55.8, 37.7)
+	db.Learn(aps[1:], 55.6, 37.5)
+
+	location, err = db.Locate(aps[1:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if math.Abs(location.Latitude-55.7) > 1e-9 || math.Abs(location.Longitude-37.6) > 1e-9 {
+		t.Error("broken learned location", location)
+	}
+
+	var b bytes.Buffer
+	if err := db.write(&b); err != nil {
+		t.Fatal(err)
+	}
+
+	saved, err := ReadWifiDB(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if saved.Len() != 2 {
+		t.Error("saved wifi db size", saved.Len())
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"Q50RT/q50"
+	"sync"
+	"time"
+)
+
+// maxDeviceHealth is the number of health measurements kept per device.
+const maxDeviceHealth = 1000
+
+// HealthLog keeps the latest health measurements per device in the order
+// they were received.
+type HealthLog struct {
+	mu      *sync.RWMutex
+	devices map[string][]q50.Health
+}
+
+func NewHealthLog() *HealthLog {
+	return &HealthLog{
+		mu:      &sync.RWMutex{},
+		devices: make(map[string][]q50.Health),
+	}
+}
+
+func (l *HealthLog) Add(deviceID string, health q50.Health) {
+	l.mu.Lock()
+	measurements := append(l.devices[deviceID], health)
+	if len(measurements) > maxDeviceHealth {
+		measurements = measurements[len(measurements)-maxDeviceHealth:]
+	}
+	l.devices[deviceID] = measurements
+	l.mu.Unlock()
+}
+
+// History returns the measurements taken in [from, to]. A zero bound
+// is open.
+func (l *HealthLog) History(deviceID string, from, to time.Time) []q50.Health {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	var result []q50.Health
+	for _, h := range l.devices[deviceID] {
+		if !from.IsZero() && h.Time.Before(from) {
+			continue
+		}
+		if !to.IsZero() && h.Time.After(to) {
+			continue
+		}
+		result = append(result, h)
+	}
+	return result
+}
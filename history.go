@@ -0,0 +1,343 @@
+package main
+
+import (
+	"Q50RT/q50"
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// segmentLayout names the daily segment files of a device.
+	segmentLayout = "20060102"
+	segmentExt    = ".csv"
+
+	// historyFields is the number of fields of a stored position.
+	historyFields = 21
+)
+
+// History stores every accepted position in append-only CSV segments,
+// one directory per device and one file per UTC day of the device time.
+// Stored positions can arrive late, so a segment is sorted when read.
+type History struct {
+	mu  *sync.RWMutex
+	dir string
+}
+
+// HistoryCursor is where a history page starts: the device time of the
+// first position and how many positions of that time were already returned.
+type HistoryCursor struct {
+	From time.Time
+	Skip int
+}
+
+func NewHistory(dir string) *History {
+	return &History{
+		mu:  &sync.RWMutex{},
+		dir: dir,
+	}
+}
+
+// Add appends a position to the segment of its day.
+func (h *History) Add(message *q50.Message) error {
+	if !validName(message.ID) {
+		return fmt.Errorf("invalid device id %q", message.ID)
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	dir := filepath.Join(h.dir, message.ID)
+	if err := os.MkdirAll(dir, 0775); err != nil {
+		return err
+	}
+
+	name := positionTime(message).UTC().Format(segmentLayout) + segmentExt
+	f, err := os.OpenFile(filepath.Join(dir, name), os.O_RDWR|os.O_CREATE|os.O_APPEND, 0664)
+	if err != nil {
+		return err
+	}
+
+	if err := endLine(f); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	w := csv.NewWriter(f)
+	if err := w.Write(encodePosition(message)); err != nil {
+		_ = f.Close()
+		return err
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// endLine terminates a record cut off by a crash, so that the next one
+// doesn't continue it.
+func endLine(f *os.File) error {
+	info, err := f.Stat()
+	if err != nil || info.Size() == 0 {
+		return err
+	}
+
+	last := make([]byte, 1)
+	if _, err := f.ReadAt(last, info.Size()-1); err != nil {
+		return err
+	}
+	if last[0] == '\n' {
+		return nil
+	}
+
+	_, err = f.Write([]byte{'\n'})
+	return err
+}
+
+// Query returns up to limit positions of the device with a device time in
+// [cursor.From, to], oldest first. A zero bound is open. The returned
+// cursor starts the next page, it is nil on the last one.
+func (h *History) Query(deviceID string, cursor HistoryCursor, to time.Time, limit int) ([]*q50.Message, *HistoryCursor, error) {
+	if !validName(deviceID) {
+		return nil, nil, fmt.Errorf("invalid device id %q", deviceID)
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	segments, err := h.segments(deviceID, cursor.From, to)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// one position past the page tells whether there is a next one
+	want := cursor.Skip + limit + 1
+	var positions []*q50.Message
+	for _, segment := range segments {
+		read, err := readSegment(segment)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, message := range read {
+			t := positionTime(message)
+			if !cursor.From.IsZero() && t.Before(cursor.From) {
+				continue
+			}
+			if !to.IsZero() && t.After(to) {
+				continue
+			}
+			positions = append(positions, message)
+		}
+
+		if len(positions) >= want {
+			break
+		}
+	}
+
+	if len(positions) <= cursor.Skip {
+		return nil, nil, nil
+	}
+	positions = positions[cursor.Skip:]
+
+	if len(positions) <= limit {
+		return positions, nil, nil
+	}
+
+	page := positions[:limit]
+	next := &HistoryCursor{From: positionTime(positions[limit])}
+	if next.From.Equal(cursor.From) {
+		next.Skip = cursor.Skip
+	}
+	for _, message := range page {
+		if positionTime(message).Equal(next.From) {
+			next.Skip++
+		}
+	}
+	return page, next, nil
+}
+
+// segments returns the segment files of the device that may hold positions
+// of [from, to], oldest first.
+func (h *History) segments(deviceID string, from, to time.Time) ([]string, error) {
+	infos, err := ioutil.ReadDir(filepath.Join(h.dir, deviceID))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var first, last string
+	if !from.IsZero() {
+		first = from.UTC().Format(segmentLayout)
+	}
+	if !to.IsZero() {
+		last = to.UTC().Format(segmentLayout)
+	}
+
+	var segments []string
+	for _, info := range infos {
+		name := info.Name()
+		if info.IsDir() || !strings.HasSuffix(name, segmentExt) {
+			continue
+		}
+
+		day := strings.TrimSuffix(name, segmentExt)
+		if _, err := time.Parse(segmentLayout, day); err != nil {
+			continue
+		}
+		if (len(first) != 0 && day < first) || (len(last) != 0 && day > last) {
+			continue
+		}
+		segments = append(segments, filepath.Join(h.dir, deviceID, name))
+	}
+
+	sort.Strings(segments)
+	return segments, nil
+}
+
+func readSegment(fileName string) ([]*q50.Message, error) {
+	f, err := os.Open(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	deviceID := filepath.Base(filepath.Dir(fileName))
+
+	// every line is parsed on its own, so that a record cut off by a crash
+	// can't take the following one with it
+	var positions []*q50.Message
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		reader := csv.NewReader(strings.NewReader(scanner.Text()))
+		reader.FieldsPerRecord = -1
+		record, err := reader.Read()
+		if err != nil {
+			continue
+		}
+
+		message, err := decodePosition(deviceID, record)
+		if err != nil {
+			continue
+		}
+		positions = append(positions, message)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(positions, func(i, j int) bool {
+		return positionTime(positions[i]).Before(positionTime(positions[j]))
+	})
+	return positions, nil
+}
+
+// positionTime returns the time a position was taken. Frames without a
+// valid date fall back to the time they were received.
+func positionTime(message *q50.Message) time.Time {
+	if message.DeviceTime.IsZero() {
+		return message.ReceiveTime
+	}
+	return message.DeviceTime
+}
+
+func encodePosition(m *q50.Message) []string {
+	var deviceTime int64
+	if !m.DeviceTime.IsZero() {
+		deviceTime = m.DeviceTime.UnixNano()
+	}
+
+	return []string{
+		strconv.FormatInt(deviceTime, 10),
+		strconv.FormatInt(m.ReceiveTime.UnixNano(), 10),
+		m.MessageType,
+		m.NetType,
+		strconv.FormatFloat(m.Latitude, 'f', -1, 64),
+		strconv.FormatFloat(m.Longitude, 'f', -1, 64),
+		strconv.FormatBool(m.GPSValid),
+		strconv.FormatFloat(m.Speed, 'f', -1, 64),
+		strconv.FormatFloat(m.Course, 'f', -1, 64),
+		strconv.FormatFloat(m.Altitude, 'f', -1, 64),
+		strconv.FormatUint(uint64(m.Satellites), 10),
+		strconv.FormatUint(uint64(m.SignalStrength), 10),
+		strconv.FormatUint(uint64(m.BatteryPercent), 10),
+		strconv.FormatUint(uint64(m.Steps), 10),
+		strconv.FormatBool(m.Historical),
+		fmt.Sprintf("%08X", m.Status.Raw),
+		m.Source,
+		strconv.FormatFloat(m.Accuracy, 'f', -1, 64),
+		m.Alarm,
+		strconv.FormatInt(int64(m.ClockSkew), 10),
+		strconv.FormatBool(m.ClockSkewed),
+	}
+}
+
+func decodePosition(deviceID string, record []string) (*q50.Message, error) {
+	if len(record) != historyFields {
+		return nil, fmt.Errorf("expected %d fields", historyFields)
+	}
+
+	deviceTime, err1 := strconv.ParseInt(record[0], 10, 64)
+	receiveTime, err2 := strconv.ParseInt(record[1], 10, 64)
+	lat, err3 := strconv.ParseFloat(record[4], 64)
+	lon, err4 := strconv.ParseFloat(record[5], 64)
+	if err1 != nil || err2 != nil || err3 != nil || err4 != nil {
+		return nil, fmt.Errorf("bad position %v", record)
+	}
+
+	status, _ := q50.ParseStatus(record[15])
+	gpsValid, _ := strconv.ParseBool(record[6])
+	historical, _ := strconv.ParseBool(record[14])
+	speed, _ := strconv.ParseFloat(record[7], 64)
+	course, _ := strconv.ParseFloat(record[8], 64)
+	altitude, _ := strconv.ParseFloat(record[9], 64)
+	satellites, _ := strconv.ParseUint(record[10], 10, 8)
+	signal, _ := strconv.ParseUint(record[11], 10, 8)
+	battery, _ := strconv.ParseUint(record[12], 10, 8)
+	steps, _ := strconv.ParseUint(record[13], 10, 32)
+	accuracy, _ := strconv.ParseFloat(record[17], 64)
+	skew, _ := strconv.ParseInt(record[19], 10, 64)
+	skewed, _ := strconv.ParseBool(record[20])
+
+	message := &q50.Message{
+		ID:             deviceID,
+		MessageType:    record[2],
+		NetType:        record[3],
+		ReceiveTime:    time.Unix(0, receiveTime),
+		Latitude:       lat,
+		Longitude:      lon,
+		GPSValid:       gpsValid,
+		Speed:          speed,
+		Course:         course,
+		Altitude:       altitude,
+		Satellites:     uint8(satellites),
+		SignalStrength: uint8(signal),
+		BatteryPercent: uint8(battery),
+		Steps:          uint32(steps),
+		Historical:     historical,
+		Status:         status,
+		Source:         record[16],
+		Accuracy:       accuracy,
+		Alarm:          record[18],
+		ClockSkew:      time.Duration(skew),
+		ClockSkewed:    skewed,
+	}
+	if deviceTime != 0 {
+		message.DeviceTime = time.Unix(0, deviceTime)
+	}
+	return message, nil
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"Q50RT/q50"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestHistory(t *testing.T) {
+	dir, err := ioutil.TempDir("", "history")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	history := NewHistory(dir)
+	start := time.Date(2018, 11, 5, 23, 59, 0, 0, time.UTC)
+
+	// a stored position arrives after the newer ones, two share a time
+	offsets := []time.Duration{0, time.Minute, time.Minute, 2 * time.Minute, -time.Minute}
+	for i, offset := range offsets {
+		message := &q50.Message{
+			ID:          "1234567890",
+			MessageType: q50.UD,
+			DeviceTime:  start.Add(offset),
+			ReceiveTime: time.Now(),
+			Latitude:    float64(i),
+			Longitude:   37.6,
+			Status:      q50.NewStatus(0x10000),
+			Historical:  offset < 0,
+		}
+		if err := history.Add(message); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var got []*q50.Message
+	cursor := HistoryCursor{}
+	for pages := 0; pages < 10; pages++ {
+		page, next, err := history.Query("1234567890", cursor, time.Time{}, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, page...)
+		if next == nil {
+			break
+		}
+		cursor = *next
+	}
+
+	if len(got) != len(offsets) {
+		t.Fatal("broken history length", len(got))
+	}
+
+	expected := []float64{4, 0, 1, 2, 3}
+	for i, message := range got {
+		if message.Latitude != expected[i] {
+			t.Error("broken history order", i, message.Latitude)
+		}
+	}
+
+	if !got[0].Historical || !got[1].Status.SOS || got[1].Longitude != 37.6 || !got[1].DeviceTime.Equal(start) {
+		t.Error("broken stored position", got[1])
+	}
+
+	page, _, err := history.Query("1234567890", HistoryCursor{From: start}, start.Add(time.Minute), 10)
+	if err != nil || len(page) != 3 {
+		t.Error("broken time range", len(page), err)
+	}
+}
+
+func TestHistoryPartialRecord(t *testing.T) {
+	dir, err := ioutil.TempDir("", "history")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	history := NewHistory(dir)
+	start := time.Date(2018, 11, 5, 10, 0, 0, 0, time.UTC)
+	add := func(lat float64, offset time.Duration) {
+		message := &q50.Message{ID: "1234567890", MessageType: q50.UD, DeviceTime: start.Add(offset), Latitude: lat}
+		if err := history.Add(message); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	add(1, 0)
+
+	// a crash cut off the last record
+	segment := filepath.Join(dir, "1234567890", "20181105"+segmentExt)
+	f, err := os.OpenFile(segment, os.O_WRONLY|os.O_APPEND, 0664)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := f.WriteString(`1541412060000000000,1541412060000000000,UD,3G,2,"3`); err != nil {
+		t.Fatal(err)
+	}
+	_ = f.Close()
+
+	add(3, 2*time.Minute)
+
+	page, _, err := history.Query("1234567890", HistoryCursor{}, time.Time{}, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(page) != 2 || page[0].Latitude != 1 || page[1].Latitude != 3 {
+		t.Error("broken positions around a partial record", page)
+	}
+}
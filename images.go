@@ -0,0 +1,67 @@
+package main
+
+import (
+	"Q50RT/q50"
+	"log"
+	"sync"
+	"time"
+)
+
+const (
+	// imagePartTimeout is how long an incomplete photo waits for its next part.
+	imagePartTimeout = 2 * time.Minute
+
+	// maxImageSize limits the data of one photo. A photo that grows past it
+	// without an end of image marker is dropped.
+	maxImageSize = 2 << 20
+)
+
+type imagePart struct {
+	captured time.Time
+	received time.Time
+	data     []byte
+}
+
+// ImageAssembler joins the img frames of one photo. The frames carry no
+// part number, so they must be added in the order the watch sent them,
+// as the frames of one connection are served. A photo is complete when
+// its data ends with the JPEG end of image marker.
+type ImageAssembler struct {
+	mu    *sync.Mutex
+	parts map[string]*imagePart
+}
+
+func NewImageAssembler() *ImageAssembler {
+	return &ImageAssembler{
+		mu:    &sync.Mutex{},
+		parts: make(map[string]*imagePart),
+	}
+}
+
+// Add appends an img frame to the device photo and returns the photo
+// once it is complete.
+func (a *ImageAssembler) Add(message *q50.Message) ([]byte, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	part, ok := a.parts[message.ID]
+	if !ok || !part.captured.Equal(message.DeviceTime) || time.Since(part.received) > imagePartTimeout {
+		part = &imagePart{captured: message.DeviceTime}
+		a.parts[message.ID] = part
+	}
+
+	if len(part.data)+len(message.Payload) > maxImageSize {
+		log.Printf("%s: photo exceeds %d bytes, dropped", message.ID, maxImageSize)
+		delete(a.parts, message.ID)
+		return nil, false
+	}
+
+	part.received = time.Now()
+	part.data = append(part.data, message.Payload...)
+	if !q50.IsImageComplete(part.data) {
+		return nil, false
+	}
+
+	delete(a.parts, message.ID)
+	return part.data, true
+}
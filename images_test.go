@@ -0,0 +1,62 @@
+package main
+
+import (
+	"Q50RT/q50"
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestImageAssembler(t *testing.T) {
+	assembler := NewImageAssembler()
+	captured := time.Date(2018, 10, 18, 15, 1, 1, 0, time.UTC)
+	part := func(captured time.Time, data ...byte) *q50.Message {
+		return &q50.Message{ID: "1234567890", MessageType: q50.IMG, DeviceTime: captured, Payload: data}
+	}
+
+	// an abandoned photo of an earlier capture is dropped
+	if _, ok := assembler.Add(part(captured.Add(-time.Minute), 0xFF, 0xD8, 0x01)); ok {
+		t.Fatal("incomplete photo returned")
+	}
+
+	parts := [][]byte{{0xFF, 0xD8, 0x00}, {0xFF, 0xD9, 0x02}, {0x03, 0xFF, 0xD9}}
+	var image []byte
+	for i, data := range parts {
+		var ok bool
+		image, ok = assembler.Add(part(captured, data...))
+		if ok != (i == len(parts)-1) {
+			t.Fatal("broken photo completion at part", i)
+		}
+	}
+
+	expected := []byte{0xFF, 0xD8, 0x00, 0xFF, 0xD9, 0x02, 0x03, 0xFF, 0xD9}
+	if !bytes.Equal(image, expected) {
+		t.Errorf("broken photo % X", image)
+	}
+
+	if _, ok := assembler.Add(part(captured, 0x04)); ok {
+		t.Error("completed photo was not reset")
+	}
+}
+
+func TestImageAssemblerMaxSize(t *testing.T) {
+	assembler := NewImageAssembler()
+	captured := time.Date(2018, 10, 18, 15, 1, 1, 0, time.UTC)
+	part := func(data []byte) *q50.Message {
+		return &q50.Message{ID: "1234567890", MessageType: q50.IMG, DeviceTime: captured, Payload: data}
+	}
+
+	data := make([]byte, maxImageSize/2)
+	data[0], data[1] = 0xFF, 0xD8
+	for i := 0; i < 3; i++ {
+		if _, ok := assembler.Add(part(data)); ok {
+			t.Fatal("incomplete photo returned")
+		}
+	}
+
+	// the oversized photo was dropped, the next part starts a new one
+	image, ok := assembler.Add(part([]byte{0xFF, 0xD8, 0x00, 0xFF, 0xD9}))
+	if !ok || len(image) != 5 {
+		t.Error("oversized photo was not dropped", len(image))
+	}
+}
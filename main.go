@@ -1,6 +1,8 @@
 package main
 
 import (
+	"Q50RT/geo"
+	"Q50RT/q50"
 	"flag"
 	"fmt"
 	"io"
@@ -8,15 +10,25 @@ import (
 	"net"
 	"os"
 	"sync"
+	"time"
 )
 
 type ServerConfig struct {
 	Host            string
 	TelemetryPort   string
 	APIPort         string
+	MetricsPort     string
 	Version         string
 	ProtocolVersion string
 	LogFileName     string
+	CellDBFileName  string
+	WifiDBFileName  string
+	VoiceDir        string
+	ImageDir        string
+	HistoryDir      string
+	DeviceZone      string
+	ZonesFileName   string
+	MaxClockSkew    time.Duration
 }
 
 type Starter struct {
@@ -29,6 +41,35 @@ var serverConfig *ServerConfig
 
 var LocalCache *Cache
 
+var Stats *Statistics
+
+var DeviceConnections *Connections
+
+var DeviceCommands *CommandQueue
+
+var DeviceAlarms *AlarmLog
+
+var DeviceHealth *HealthLog
+
+var VoiceStore *MediaStore
+
+var ImageStore *MediaStore
+
+var DeviceImages *ImageAssembler
+
+var PositionHistory *History
+
+var DeviceZones *Zones
+
+var PointUpdates *PointBroker
+
+var CellDB *geo.CellDB
+
+var WifiDB *geo.WifiDB
+
+// wifiDBSaveInterval is how often learned access points are written to disk.
+const wifiDBSaveInterval = 5 * time.Minute
+
 func init() {
 	serverConfig = new(ServerConfig)
 	serverConfig.Version = "0.0.1.12"
@@ -37,14 +78,25 @@ func init() {
 	serverConfig.Host = "127.0.0.1"
 	serverConfig.TelemetryPort = "30731"
 	serverConfig.APIPort = "30732"
+	serverConfig.MetricsPort = "30733"
 
 	flag.StringVar(&serverConfig.Host, "host", "127.0.0.1", "-host=127.0.0.1")
 	flag.StringVar(&serverConfig.TelemetryPort, "tlm_port", "30731", "-tlm_port=30731")
 	flag.StringVar(&serverConfig.APIPort, "api_port", "30732", "-api_port=30732")
-	flag.Parse()
+	flag.StringVar(&serverConfig.MetricsPort, "metrics_port", "30733", "-metrics_port=30733, empty disables metrics")
+	flag.StringVar(&serverConfig.CellDBFileName, "cell_db", "", "-cell_db=cells.csv")
+	flag.StringVar(&serverConfig.WifiDBFileName, "wifi_db", "", "-wifi_db=wifi.csv")
+	flag.StringVar(&serverConfig.VoiceDir, "voice_dir", "voice", "-voice_dir=voice")
+	flag.StringVar(&serverConfig.ImageDir, "image_dir", "images", "-image_dir=images")
+	flag.StringVar(&serverConfig.HistoryDir, "history_dir", "history", "-history_dir=history")
+	flag.StringVar(&serverConfig.DeviceZone, "device_tz", "0", "-device_tz=3")
+	flag.StringVar(&serverConfig.ZonesFileName, "zones_file", "zones.csv", "-zones_file=zones.csv")
+	flag.DurationVar(&serverConfig.MaxClockSkew, "max_clock_skew", q50.DefaultMaxClockSkew, "-max_clock_skew=5m")
 }
 
 func main() {
+	flag.Parse()
+
 	f, err := os.OpenFile(serverConfig.LogFileName, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0664)
 	if err != nil {
 		fmt.Printf("error opening file: %v", err)
@@ -55,7 +107,51 @@ func main() {
 	mw := io.MultiWriter(os.Stdout, f)
 	log.SetOutput(mw)
 
+	Stats = NewStatistics()
 	LocalCache = NewCache()
+	DeviceConnections = NewConnections()
+	DeviceCommands = NewCommandQueue()
+	DeviceAlarms = NewAlarmLog()
+	DeviceHealth = NewHealthLog()
+	VoiceStore = NewMediaStore(serverConfig.VoiceDir, ".amr")
+	ImageStore = NewMediaStore(serverConfig.ImageDir, ".jpg")
+	DeviceImages = NewImageAssembler()
+	PointUpdates = NewPointBroker()
+	PositionHistory = NewHistory(serverConfig.HistoryDir)
+
+	zone, err := q50.Zone(serverConfig.DeviceZone)
+	if err != nil {
+		log.Printf("error reading device time zone: %v", err)
+		zone = time.UTC
+	}
+	DeviceZones, err = LoadZones(serverConfig.ZonesFileName, zone)
+	if err != nil {
+		log.Printf("error loading device time zones: %v", err)
+		DeviceZones = NewZones(zone)
+	}
+
+	if len(serverConfig.CellDBFileName) != 0 {
+		CellDB, err = geo.LoadCellDB(serverConfig.CellDBFileName)
+		if err != nil {
+			log.Printf("error loading cell db: %v", err)
+		} else {
+			log.Printf("loaded %d cell towers from %s", CellDB.Len(), serverConfig.CellDBFileName)
+		}
+	}
+
+	if len(serverConfig.WifiDBFileName) != 0 {
+		WifiDB, err = geo.LoadWifiDB(serverConfig.WifiDBFileName)
+		if err != nil {
+			log.Printf("error loading wifi db: %v", err)
+		} else {
+			log.Printf("loaded %d wifi access points from %s", WifiDB.Len(), serverConfig.WifiDBFileName)
+			go runWifiDBSaver(WifiDB, serverConfig.WifiDBFileName)
+		}
+	}
+
+	if len(serverConfig.MetricsPort) != 0 {
+		go StartMetricsServer(serverConfig)
+	}
 
 	starter := &Starter{
 		waitGroup: &sync.WaitGroup{},
@@ -69,6 +165,10 @@ func main() {
 		},
 	}
 	starter.run()
+
+	if WifiDB != nil {
+		saveWifiDB(WifiDB, serverConfig.WifiDBFileName)
+	}
 }
 
 func (s *Starter) run() {
@@ -84,3 +184,20 @@ func (c *ServerConfig) telemetryAddr() string {
 func (c *ServerConfig) APIAddr() string {
 	return net.JoinHostPort(c.Host, c.APIPort)
 }
+
+func (c *ServerConfig) metricsAddr() string {
+	return net.JoinHostPort(c.Host, c.MetricsPort)
+}
+
+func runWifiDBSaver(db *geo.WifiDB, fileName string) {
+	ticker := time.NewTicker(wifiDBSaveInterval)
+	for range ticker.C {
+		saveWifiDB(db, fileName)
+	}
+}
+
+func saveWifiDB(db *geo.WifiDB, fileName string) {
+	if err := db.Save(fileName); err != nil {
+		log.Printf("error saving wifi db: %v", err)
+	}
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var ErrMediaNotFound = errors.New("media file not found")
+
+// MediaFile describes a file received from a device.
+type MediaFile struct {
+	DeviceID string
+	Name     string
+	Time     time.Time
+	Size     int64
+}
+
+// MediaStore saves binary uploads as files in one directory per device.
+// A file is named after the time it was received.
+type MediaStore struct {
+	mu  *sync.Mutex
+	dir string
+	ext string
+}
+
+func NewMediaStore(dir, ext string) *MediaStore {
+	return &MediaStore{
+		mu:  &sync.Mutex{},
+		dir: dir,
+		ext: ext,
+	}
+}
+
+func (s *MediaStore) Save(deviceID string, received time.Time, data []byte) (MediaFile, error) {
+	if !validName(deviceID) {
+		return MediaFile{}, fmt.Errorf("invalid device id %q", deviceID)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	dir := filepath.Join(s.dir, deviceID)
+	if err := os.MkdirAll(dir, 0775); err != nil {
+		return MediaFile{}, err
+	}
+
+	// files received in the same nanosecond get the next free name
+	ts := received.UnixNano()
+	name := strconv.FormatInt(ts, 10) + s.ext
+	for {
+		if _, err := os.Stat(filepath.Join(dir, name)); os.IsNotExist(err) {
+			break
+		}
+		ts++
+		name = strconv.FormatInt(ts, 10) + s.ext
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(dir, name), data, 0664); err != nil {
+		return MediaFile{}, err
+	}
+
+	return MediaFile{
+		DeviceID: deviceID,
+		Name:     name,
+		Time:     time.Unix(0, ts),
+		Size:     int64(len(data)),
+	}, nil
+}
+
+// List returns the device files, oldest first.
+func (s *MediaStore) List(deviceID string) ([]MediaFile, error) {
+	if !validName(deviceID) {
+		return nil, fmt.Errorf("invalid device id %q", deviceID)
+	}
+
+	infos, err := ioutil.ReadDir(filepath.Join(s.dir, deviceID))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var files []MediaFile
+	for _, info := range infos {
+		name := info.Name()
+		if info.IsDir() || !strings.HasSuffix(name, s.ext) {
+			continue
+		}
+
+		ts, err := strconv.ParseInt(strings.TrimSuffix(name, s.ext), 10, 64)
+		if err != nil {
+			continue
+		}
+
+		files = append(files, MediaFile{
+			DeviceID: deviceID,
+			Name:     name,
+			Time:     time.Unix(0, ts),
+			Size:     info.Size(),
+		})
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Time.Before(files[j].Time)
+	})
+	return files, nil
+}
+
+func (s *MediaStore) Read(deviceID, name string) ([]byte, error) {
+	if !validName(deviceID) || !validName(name) || !strings.HasSuffix(name, s.ext) {
+		return nil, ErrMediaNotFound
+	}
+
+	data, err := ioutil.ReadFile(filepath.Join(s.dir, deviceID, name))
+	if os.IsNotExist(err) {
+		return nil, ErrMediaNotFound
+	}
+	return data, err
+}
+
+// validName reports whether v can be used as a single path element.
+func validName(v string) bool {
+	return len(v) != 0 && v != "." && v != ".." && !strings.ContainsAny(v, `/\`)
+}
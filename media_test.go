@@ -0,0 +1,54 @@
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestMediaStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "media")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = os.RemoveAll(dir)
+	}()
+
+	store := NewMediaStore(dir, ".amr")
+	received := time.Now()
+
+	first, err := store.Save("1234567890", received, []byte("#!AMR\n1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	second, err := store.Save("1234567890", received, []byte("#!AMR\n22"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if first.Name == second.Name {
+		t.Error("files received at the same time have the same name", first.Name)
+	}
+
+	files, err := store.List("1234567890")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(files) != 2 || files[0] != first || files[1].Size != 8 {
+		t.Error("broken file list", files)
+	}
+
+	data, err := store.Read("1234567890", second.Name)
+	if err != nil || !bytes.Equal(data, []byte("#!AMR\n22")) {
+		t.Error("broken file data", data, err)
+	}
+
+	if _, err := store.Read("1234567890", "../"+second.Name); err != ErrMediaNotFound {
+		t.Error("file outside the device directory read", err)
+	}
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+const metricsNamespace = "q50"
+
+var (
+	framesReceived = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "frames_received_total",
+		Help:      "Valid frames received from the watches by message type, other for undefined types.",
+	}, []string{"type"})
+
+	frameErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "frame_errors_total",
+		Help:      "Frames that couldn't be split (framing) or decoded (parse).",
+	}, []string{"kind"})
+
+	processingSeconds = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "processing_seconds",
+		Help:      "Time to decode and store one frame.",
+		Buckets:   prometheus.ExponentialBuckets(0.0001, 4, 10),
+	})
+
+	clockSkewSeconds = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "clock_skew_seconds",
+		Help:      "Absolute difference between the receive and the device time of live positions.",
+		Buckets:   []float64{1, 5, 30, 60, 300, 900, 3600, 6 * 3600, 24 * 3600},
+	})
+
+	apiSeconds = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "api_call_seconds",
+		Help:      "gRPC call latency by method and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "code"})
+)
+
+// newMetricsRegistry registers the collectors of the servers. Gauges of
+// the server state are read when scraped.
+func newMetricsRegistry() *prometheus.Registry {
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		framesReceived,
+		frameErrors,
+		processingSeconds,
+		clockSkewSeconds,
+		apiSeconds,
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "connections_active",
+			Help:      "Open TCP connections from the watches.",
+		}, func() float64 {
+			clients, _ := DeviceConnections.Count()
+			return float64(clients)
+		}),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "devices_online",
+			Help:      "Watches bound to an open connection.",
+		}, func() float64 {
+			_, devices := DeviceConnections.Count()
+			return float64(devices)
+		}),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "point_subscribers",
+			Help:      "Open SubscribePoints streams.",
+		}, func() float64 {
+			return float64(PointUpdates.Len())
+		}),
+		prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "cache_items",
+			Help:      "Last points held in the cache.",
+		}, func() float64 {
+			return float64(LocalCache.Len())
+		}),
+		prometheus.NewCounterFunc(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "cache_evictions_total",
+			Help:      "Expired last points removed from the cache.",
+		}, func() float64 {
+			return float64(LocalCache.Evicted())
+		}),
+	)
+	return registry
+}
+
+func StartMetricsServer(c *ServerConfig) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(newMetricsRegistry(), promhttp.HandlerOpts{}))
+
+	log.Printf("Q50Watch metrics server started on address: %v", c.metricsAddr())
+	if err := http.ListenAndServe(c.metricsAddr(), mux); err != nil {
+		log.Printf("Fatal error: %s", err.Error())
+	}
+}
+
+// observeCall records the latency of an API call.
+func observeCall(fullMethod string, started time.Time, err error) {
+	apiSeconds.WithLabelValues(methodName(fullMethod), status.Code(err).String()).
+		Observe(time.Since(started).Seconds())
+}
+
+// unaryMetrics times the unary API calls.
+func unaryMetrics(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+	started := time.Now()
+	resp, err := handler(ctx, req)
+	observeCall(info.FullMethod, started, err)
+	return resp, err
+}
+
+// streamMetrics times the API streams until they end.
+func streamMetrics(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) error {
+	started := time.Now()
+	err := handler(srv, ss)
+	observeCall(info.FullMethod, started, err)
+	return err
+}
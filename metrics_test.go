@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestMetricsRegistry(t *testing.T) {
+	LocalCache = NewCache()
+	DeviceConnections = NewConnections()
+	PointUpdates = NewPointBroker()
+	Stats = NewStatistics()
+
+	LocalCache.Set("1234567890", "point")
+	Stats.Frame("UD", 180)
+	for i := 0; i < 10; i++ {
+		Stats.Frame(fmt.Sprintf("X%d", i), 10)
+	}
+
+	families, err := newMetricsRegistry().Gather()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	values := make(map[string]float64)
+	for _, family := range families {
+		if family.GetName() == "q50_frames_received_total" && len(family.GetMetric()) > 2 {
+			t.Error("a frame type label per unknown type", len(family.GetMetric()))
+		}
+		for _, metric := range family.GetMetric() {
+			switch {
+			case metric.GetGauge() != nil:
+				values[family.GetName()] = metric.GetGauge().GetValue()
+			case metric.GetCounter() != nil:
+				values[family.GetName()] += metric.GetCounter().GetValue()
+			}
+		}
+	}
+
+	if values["q50_cache_items"] != 1 || values["q50_frames_received_total"] < 1 {
+		t.Error("broken metrics", values["q50_cache_items"], values["q50_frames_received_total"])
+	}
+}
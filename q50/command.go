@@ -0,0 +1,135 @@
+package q50
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Downlink commands. The watch answers each of them with a frame of the
+// same type.
+const (
+	UPLOAD   = "UPLOAD"
+	SOS      = "SOS"
+	CENTER   = "CENTER"
+	MONITOR  = "MONITOR"
+	FIND     = "FIND"
+	POWEROFF = "POWEROFF"
+	RESET    = "RESET"
+	FACTORY  = "FACTORY"
+	LZ       = "LZ"
+)
+
+// Commands lists the downlink commands with their number of arguments.
+var Commands = map[string]int{
+	UPLOAD:   1,
+	SOS:      3,
+	CENTER:   1,
+	MONITOR:  1,
+	FIND:     0,
+	POWEROFF: 0,
+	RESET:    0,
+	FACTORY:  0,
+	LZ:       2,
+	RCAPTURE: 0,
+}
+
+var (
+	ErrBadCommand  = errors.New("bad command")
+	ErrFrameTooBig = errors.New("frame content too big")
+)
+
+// Command is a downlink message, the content of a frame.
+type Command struct {
+	Name string
+	Args []string
+}
+
+// CommandName returns the name of a downlink command matched regardless
+// of case, as the watch expects it.
+func CommandName(name string) (string, bool) {
+	for command := range Commands {
+		if strings.EqualFold(command, name) {
+			return command, true
+		}
+	}
+	return "", false
+}
+
+// Encode builds the [vendor*ID*LLLL*CMD,args] frame of a command.
+func Encode(vendor, id string, cmd Command) ([]byte, error) {
+	if !validHeaderField(vendor) || !validHeaderField(id) {
+		return nil, ErrBadHeader
+	}
+
+	if len(cmd.Name) == 0 || strings.ContainsAny(cmd.Name, ",[]*") {
+		return nil, ErrBadCommand
+	}
+
+	for _, arg := range cmd.Args {
+		if strings.ContainsAny(arg, ",[]") {
+			return nil, ErrBadCommand
+		}
+	}
+
+	content := cmd.Content()
+	if len(content) > MaxContentLen {
+		return nil, ErrFrameTooBig
+	}
+
+	frame := &Frame{Vendor: vendor, ID: id, Content: content}
+	return frame.Bytes(), nil
+}
+
+// Content returns the frame content of the command, e.g. UPLOAD,600.
+func (cmd Command) Content() []byte {
+	return []byte(strings.Join(append([]string{cmd.Name}, cmd.Args...), ","))
+}
+
+func validHeaderField(v string) bool {
+	return len(v) != 0 && !strings.ContainsAny(v, "[]*")
+}
+
+// Upload sets the interval of the watch position reports.
+func Upload(interval time.Duration) Command {
+	return Command{Name: UPLOAD, Args: []string{strconv.Itoa(int(interval / time.Second))}}
+}
+
+// SOSNumbers sets the three numbers the watch calls on SOS.
+func SOSNumbers(first, second, third string) Command {
+	return Command{Name: SOS, Args: []string{first, second, third}}
+}
+
+// Center sets the center number that receives the watch alarms.
+func Center(phone string) Command {
+	return Command{Name: CENTER, Args: []string{phone}}
+}
+
+// Monitor makes the watch call phone back with its microphone on.
+func Monitor(phone string) Command {
+	return Command{Name: MONITOR, Args: []string{phone}}
+}
+
+// Find makes the watch ring.
+func Find() Command {
+	return Command{Name: FIND}
+}
+
+func PowerOff() Command {
+	return Command{Name: POWEROFF}
+}
+
+func Reset() Command {
+	return Command{Name: RESET}
+}
+
+// Factory restores the factory settings.
+func Factory() Command {
+	return Command{Name: FACTORY}
+}
+
+// Language sets the watch language code and time zone offset in hours.
+func Language(language, timezone int) Command {
+	return Command{Name: LZ, Args: []string{strconv.Itoa(language), strconv.Itoa(timezone)}}
+}
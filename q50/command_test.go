@@ -0,0 +1,61 @@
+package q50
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+var commandTests = []struct {
+	TestName string
+	Command  Command
+	Frame    string
+}{
+	{"Upload", Upload(10 * time.Minute), "[3G*1234567890*000A*UPLOAD,600]"},
+	{"SOS numbers", SOSNumbers("111", "222", "333"), "[3G*1234567890*000F*SOS,111,222,333]"},
+	{"Center", Center("79001234567"), "[3G*1234567890*0012*CENTER,79001234567]"},
+	{"Monitor", Monitor("79001234567"), "[3G*1234567890*0013*MONITOR,79001234567]"},
+	{"Find", Find(), "[3G*1234567890*0004*FIND]"},
+	{"Power off", PowerOff(), "[3G*1234567890*0008*POWEROFF]"},
+	{"Reset", Reset(), "[3G*1234567890*0005*RESET]"},
+	{"Factory", Factory(), "[3G*1234567890*0007*FACTORY]"},
+	{"Language", Language(1, -3), "[3G*1234567890*0007*LZ,1,-3]"},
+}
+
+func TestEncode(t *testing.T) {
+	for _, test := range commandTests {
+		t.Run(test.TestName, func(t *testing.T) {
+			b, err := Encode("3G", "1234567890", test.Command)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(b) != test.Frame {
+				t.Errorf("got %s, want %s", b, test.Frame)
+			}
+
+			frame, n, err := ReadFrame(b)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if n != len(b) || frame.Vendor != "3G" || frame.ID != "1234567890" || frame.Type() != test.Command.Name {
+				t.Error("broken frame header", frame)
+			}
+
+			if len(test.Command.Args) != 0 && !reflect.DeepEqual(frame.Args(), test.Command.Args) {
+				t.Error("broken frame args", frame.Args())
+			}
+		})
+	}
+}
+
+func TestEncodeErrors(t *testing.T) {
+	if _, err := Encode("3G", "", Find()); err != ErrBadHeader {
+		t.Error("empty id encoded", err)
+	}
+
+	if _, err := Encode("3G", "1234567890", Center("1,2")); err != ErrBadCommand {
+		t.Error("comma in argument encoded", err)
+	}
+}
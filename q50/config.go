@@ -0,0 +1,43 @@
+package q50
+
+import (
+	"strings"
+	"time"
+)
+
+// DeviceConfig is the model and feature set a watch reports in its
+// CONFIG frame. Values holds every key, including the ones without a field.
+//
+// Temperature support has no field: none of the CONFIG keys the watches
+// send is known to flag it. Watches that measure it report BTEMP2 frames.
+// Clients look up the key in Values once the vendor documents it.
+type DeviceConfig struct {
+	Model          string
+	UploadInterval time.Duration
+	HeartRate      bool
+	WiFi           bool
+	Video          bool
+	Photo          bool
+	Values         map[string]string
+}
+
+func parseCONFIG(message *Message, args []string) {
+	//[3G*1234567890*007E*CONFIG,TY:g36,UL:300,SY:0,CM:0,WT:0,HR:0,TB:1,CS:0,PP:0,AB:1,HH:1,TR:0,MO:0,FL:1,VD:0,DD:0,SD:0,XY:0,WF:0,WX:0,PH:0,RW:0,MT:1,]
+	config := &DeviceConfig{Values: make(map[string]string)}
+	for _, arg := range args {
+		i := strings.IndexByte(arg, ':')
+		if i == -1 {
+			continue
+		}
+		config.Values[arg[:i]] = arg[i+1:]
+	}
+
+	config.Model = config.Values["TY"]
+	config.UploadInterval = time.Duration(toUint32(config.Values["UL"])) * time.Second
+	config.HeartRate = config.Values["HR"] == "1"
+	config.WiFi = config.Values["WF"] == "1"
+	config.Video = config.Values["VD"] == "1"
+	config.Photo = config.Values["PH"] == "1"
+
+	message.Config = config
+}
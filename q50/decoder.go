@@ -0,0 +1,68 @@
+package q50
+
+import (
+	"io"
+)
+
+// readChunkLen is the number of bytes the decoder asks the reader for.
+const readChunkLen = 4096
+
+// Decoder reads frames and messages from a stream. Frames may be split
+// across reads or share one. Bytes outside of a frame are skipped.
+type Decoder struct {
+	r   io.Reader
+	buf []byte
+	err error
+}
+
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{r: r}
+}
+
+// ReadFrame returns the next frame. A malformed frame is reported with
+// its error and skipped, so the next call continues with the following
+// frame. io.EOF is returned at the end of the stream, io.ErrUnexpectedEOF
+// if it ends inside a frame.
+func (d *Decoder) ReadFrame() (*Frame, error) {
+	for {
+		frame, _, advance, err := NextFrame(d.buf, d.err != nil)
+		d.buf = d.buf[advance:]
+
+		switch {
+		case frame != nil:
+			frame.Content = append([]byte(nil), frame.Content...)
+			return frame, nil
+		case err == ErrTruncated && d.err != nil:
+			if d.err == io.EOF {
+				return nil, io.ErrUnexpectedEOF
+			}
+			return nil, d.err
+		case err != nil:
+			return nil, err
+		case d.err != nil:
+			return nil, d.err
+		}
+
+		d.fill()
+	}
+}
+
+// Decode returns the message of the next frame. Errors are reported as
+// by ReadFrame, a frame with malformed content is skipped as well.
+func (d *Decoder) Decode() (*Message, error) {
+	frame, err := d.ReadFrame()
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseFrame(frame)
+}
+
+func (d *Decoder) fill() {
+	var chunk [readChunkLen]byte
+	n, err := d.r.Read(chunk[:])
+	d.buf = append(d.buf, chunk[:n]...)
+	if err != nil {
+		d.err = err
+	}
+}
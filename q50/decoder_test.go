@@ -0,0 +1,62 @@
+package q50
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestDecoder(t *testing.T) {
+	stream := "garbage[3G*1234567890*000D*LK,23227,0,73][3G*1234567890*0002*LK]\r\n" +
+		"[3G*1234567890*00ZZ*LK]xx[3G*1234567890*0009*oxygen,98][3G*1234567890*000D*LK,2"
+
+	// one byte per read splits every frame across reads
+	decoder := NewDecoder(iotest.OneByteReader(strings.NewReader(stream)))
+
+	var types []string
+	var errs []error
+	for {
+		message, err := decoder.Decode()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		types = append(types, message.MessageType)
+	}
+
+	if strings.Join(types, " ") != "LK LK oxygen" {
+		t.Error("broken decoded messages", types)
+	}
+
+	if len(errs) != 2 || errs[0] != ErrBadLength || errs[1] != io.ErrUnexpectedEOF {
+		t.Error("broken decoder errors", errs)
+	}
+}
+
+func TestNextFrame(t *testing.T) {
+	tests := []struct {
+		data    string
+		atEOF   bool
+		found   bool
+		start   int
+		advance int
+		err     error
+	}{
+		{"xx[3G*1234567890*0002*LK]yy", false, true, 2, 25, nil},
+		{"xx[3G*1234567890*0005*LK,2", false, false, 2, 2, nil},
+		{"xx[3G*1234567890*0005*LK,2", true, false, 2, 26, ErrTruncated},
+		{"xx[3G*1234567890*00ZZ*LK][3G", false, false, 2, 3, ErrBadLength},
+		{"no frame", false, false, 8, 8, nil},
+	}
+
+	for _, test := range tests {
+		frame, start, advance, err := NextFrame([]byte(test.data), test.atEOF)
+		if (frame != nil) != test.found || start != test.start || advance != test.advance || err != test.err {
+			t.Errorf("%q: got %v %d %d %v", test.data, frame, start, advance, err)
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package q50
+
+import (
+	"errors"
+)
+
+const escapeByte = 0x7D
+
+var ErrBadEscape = errors.New("bad escape sequence")
+
+// escapes maps the bytes that can't appear raw in a binary payload to the
+// byte following 0x7D in their escape sequence.
+var escapes = map[byte]byte{
+	0x7D: 0x01,
+	'[':  0x02,
+	']':  0x03,
+	',':  0x04,
+	'*':  0x05,
+}
+
+// Escape encodes 0x7D, '[', ']', ',' and '*' in binary data as 0x7D 0x01..0x05.
+func Escape(data []byte) []byte {
+	b := make([]byte, 0, len(data))
+	for _, c := range data {
+		if e, ok := escapes[c]; ok {
+			b = append(b, escapeByte, e)
+			continue
+		}
+		b = append(b, c)
+	}
+	return b
+}
+
+// Unescape decodes the escape sequences of Escape.
+func Unescape(data []byte) ([]byte, error) {
+	b := make([]byte, 0, len(data))
+	for i := 0; i < len(data); i++ {
+		if data[i] != escapeByte {
+			b = append(b, data[i])
+			continue
+		}
+
+		if i+1 == len(data) || data[i+1] < 0x01 || data[i+1] > 0x05 {
+			return nil, ErrBadEscape
+		}
+		i++
+		b = append(b, unescapes[data[i]])
+	}
+	return b, nil
+}
+
+var unescapes = [...]byte{0x01: 0x7D, 0x02: '[', 0x03: ']', 0x04: ',', 0x05: '*'}
@@ -0,0 +1,128 @@
+package q50
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestEscape(t *testing.T) {
+	data := []byte{0x01, 0x7D, '[', ']', ',', '*', 0xFF}
+	escaped := Escape(data)
+
+	want := []byte{0x01, 0x7D, 0x01, 0x7D, 0x02, 0x7D, 0x03, 0x7D, 0x04, 0x7D, 0x05, 0xFF}
+	if !bytes.Equal(escaped, want) {
+		t.Errorf("got % X, want % X", escaped, want)
+	}
+
+	unescaped, err := Unescape(escaped)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(unescaped, data) {
+		t.Errorf("got % X, want % X", unescaped, data)
+	}
+
+	if _, err := Unescape([]byte{0x7D, 0x06}); err != ErrBadEscape {
+		t.Error("bad escape decoded", err)
+	}
+}
+
+func TestParseTK(t *testing.T) {
+	audio := append([]byte("#!AMR\n"), 0x3C, ']', 0x7D, ',', '*', '[', 0x00)
+	frame := &Frame{Vendor: "3G", ID: "1234567890", Content: append([]byte("TK,"), Escape(audio)...)}
+
+	b := frame.Bytes()
+	message, err := Parse(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(message.Payload, audio) {
+		t.Errorf("got % X, want % X", message.Payload, audio)
+	}
+
+	f, _, err := ReadFrame(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(Reply(f)) != "[3G*1234567890*0004*TK,1]" {
+		t.Error("broken voice reply", string(Reply(f)))
+	}
+
+	f, _, err = ReadFrame([]byte("[3G*1234567890*0004*TK,1]"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if Reply(f) != nil {
+		t.Error("voice acknowledgement is acknowledged")
+	}
+}
+
+func TestVoice(t *testing.T) {
+	// mode 7 (12.2 kbit/s) frames are 32 bytes with the ToC byte
+	frame := append([]byte{0x3C}, bytes.Repeat([]byte{']'}, 31)...)
+	audio := append([]byte("#!AMR\n"), bytes.Repeat(frame, 2000)...)
+
+	commands, err := Voice(audio)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(commands) != 2 {
+		t.Fatal("voice commands", len(commands))
+	}
+
+	var joined []byte
+	for _, cmd := range commands {
+		b, err := Encode("3G", "1234567890", cmd)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		message, err := Parse(&b)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if (len(message.Payload)-6)%32 != 0 {
+			t.Error("voice part is split inside an AMR frame", len(message.Payload))
+		}
+		joined = append(joined, message.Payload[6:]...)
+	}
+
+	if !bytes.Equal(joined, audio[6:]) {
+		t.Error("voice parts don't add up to the audio")
+	}
+
+	if _, err := Voice([]byte("#!AMR\n\x3C\x00")); err != ErrBadAMR {
+		t.Error("truncated AMR frame accepted", err)
+	}
+}
+
+func TestParseIMG(t *testing.T) {
+	image := []byte{0xFF, 0xD8, 0x2C, 0x5D, 0x00, 0xFF, 0xD9}
+	frame := &Frame{Vendor: "3G", ID: "1234567890", Content: append([]byte("img,5,181018150101,"), Escape(image)...)}
+
+	b := frame.Bytes()
+	message, err := Parse(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(message.Payload, image) || !IsImageComplete(message.Payload) {
+		t.Errorf("got % X, want % X", message.Payload, image)
+	}
+
+	if message.DeviceTime.Format(time.RFC3339) != "2018-10-18T15:01:01Z" {
+		t.Error("broken capture time", message.DeviceTime)
+	}
+
+	b = []byte("[3G*1234567890*0005*img,5]")
+	if _, err := Parse(&b); err != ErrShortFrame {
+		t.Error("short img frame accepted", err)
+	}
+}
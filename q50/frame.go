@@ -0,0 +1,176 @@
+package q50
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// lengthFieldLen is the number of hex digits in the LLLL header field.
+const lengthFieldLen = 4
+
+// MaxContentLen is the longest content the length field can describe.
+const MaxContentLen = 0xFFFF
+
+// MaxFrameLen bounds a whole frame: the longest content plus the header.
+const MaxFrameLen = MaxContentLen + 64
+
+var (
+	ErrTruncated      = errors.New("truncated frame")
+	ErrBadLength      = errors.New("bad length field")
+	ErrLengthMismatch = errors.New("frame length mismatch")
+	ErrBadHeader      = errors.New("bad frame header")
+	ErrShortFrame     = errors.New("short frame")
+)
+
+// Frame is a single [vendor*ID*LLLL*content] packet where LLLL is the
+// hex encoded byte count of content.
+type Frame struct {
+	Vendor  string
+	ID      string
+	Length  int
+	Content []byte
+}
+
+// ReadFrame reads one frame from the beginning of data and returns it with
+// the number of bytes consumed. ErrTruncated is returned when data ends
+// before the frame is complete, so the caller may wait for more bytes.
+func ReadFrame(data []byte) (*Frame, int, error) {
+	if len(data) == 0 {
+		return nil, 0, ErrTruncated
+	}
+
+	if data[0] != '[' {
+		return nil, 0, errors.New("expected [")
+	}
+
+	pos := 1
+	vendor, n := readHeaderField(data[pos:])
+	if n == -1 {
+		return nil, 0, headerError(data[pos:])
+	}
+	pos += n + 1
+
+	id, n := readHeaderField(data[pos:])
+	if n == -1 {
+		return nil, 0, headerError(data[pos:])
+	}
+	pos += n + 1
+
+	if len(vendor) == 0 || len(id) == 0 {
+		return nil, 0, ErrBadHeader
+	}
+
+	if len(data) < pos+lengthFieldLen+1 {
+		return nil, 0, ErrTruncated
+	}
+
+	length, err := strconv.ParseUint(string(data[pos:pos+lengthFieldLen]), 16, 16)
+	if err != nil || data[pos+lengthFieldLen] != '*' {
+		return nil, 0, ErrBadLength
+	}
+	pos += lengthFieldLen + 1
+
+	end := pos + int(length)
+	if len(data) < end+1 {
+		return nil, 0, ErrTruncated
+	}
+
+	if data[end] != ']' {
+		return nil, 0, ErrLengthMismatch
+	}
+
+	frame := &Frame{
+		Vendor:  vendor,
+		ID:      id,
+		Length:  int(length),
+		Content: data[pos:end],
+	}
+
+	return frame, end + 1, nil
+}
+
+// NextFrame finds the next frame in data, skipping the bytes before its '['.
+// It returns the frame, the offset of its '[' and the number of bytes the
+// caller is done with. Without a frame or error, data ends before the next
+// frame is complete and the bytes from advance on must be kept until more
+// arrive. If atEOF is set no more will arrive and a cut off frame is
+// ErrTruncated. A malformed frame only advances past its '[', so the next
+// call resyncs on the following one.
+func NextFrame(data []byte, atEOF bool) (frame *Frame, start, advance int, err error) {
+	start = bytes.IndexByte(data, '[')
+	if start == -1 {
+		return nil, len(data), len(data), nil
+	}
+
+	frame, n, err := ReadFrame(data[start:])
+	switch {
+	case err == nil:
+		return frame, start, start + n, nil
+	case err == ErrTruncated && atEOF:
+		return nil, start, len(data), err
+	case err == ErrTruncated && len(data)-start <= MaxFrameLen:
+		return nil, start, start, nil
+	}
+	return nil, start, start + 1, err
+}
+
+// readHeaderField returns the text before the next '*' and its length,
+// or -1 if there is no '*' in the allowed header range.
+func readHeaderField(data []byte) (string, int) {
+	i := bytes.IndexByte(data, '*')
+	if i == -1 || bytes.IndexAny(data[:i], "[]") != -1 {
+		return "", -1
+	}
+	return string(data[:i]), i
+}
+
+func headerError(data []byte) error {
+	if bytes.IndexAny(data, "[]") == -1 {
+		return ErrTruncated
+	}
+	return ErrBadHeader
+}
+
+// Type returns the message type, the content up to the first comma.
+func (f *Frame) Type() string {
+	i := bytes.IndexByte(f.Content, ',')
+	if i == -1 {
+		return string(f.Content)
+	}
+	return string(f.Content[:i])
+}
+
+// Args returns the comma separated fields following the message type.
+func (f *Frame) Args() []string {
+	i := bytes.IndexByte(f.Content, ',')
+	if i == -1 {
+		return nil
+	}
+	return splitArgs(string(f.Content[i+1:]))
+}
+
+// Size returns the number of bytes of the encoded frame.
+func (f *Frame) Size() int {
+	return len(f.Vendor) + len(f.ID) + lengthFieldLen + len(f.Content) + len("[***]")
+}
+
+// Bytes encodes the frame, computing the length field from the content.
+func (f *Frame) Bytes() []byte {
+	header := fmt.Sprintf("[%s*%s*%04X*", f.Vendor, f.ID, len(f.Content))
+	b := make([]byte, 0, len(header)+len(f.Content)+1)
+	b = append(b, header...)
+	b = append(b, f.Content...)
+	return append(b, ']')
+}
+
+// Payload returns the raw bytes following the message type. Unlike Args
+// it is safe for binary content.
+func (f *Frame) Payload() []byte {
+	i := bytes.IndexByte(f.Content, ',')
+	if i == -1 {
+		return nil
+	}
+	return f.Content[i+1:]
+}
@@ -0,0 +1,79 @@
+package q50
+
+import (
+	"time"
+)
+
+// Health frames
+const (
+	BPHRT    = "bphrt"
+	HRTSTART = "hrtstart"
+	OXYGEN   = "oxygen"
+	BTEMP2   = "btemp2"
+)
+
+// Health is a measurement taken by the watch sensors. Values that the
+// frame doesn't carry are zero.
+type Health struct {
+	Time        time.Time
+	HeartRate   uint16
+	Systolic    uint16
+	Diastolic   uint16
+	SpO2        uint8
+	Temperature float64
+}
+
+func parseBPHRT(message *Message, args []string) {
+	//[3G*1234567890*0013*bphrt,120,79,73,,,,]
+	if len(args) < 3 {
+		return
+	}
+
+	message.Health = &Health{
+		Time:      message.ReceiveTime,
+		Systolic:  uint16(toUint32(args[0])),
+		Diastolic: uint16(toUint32(args[1])),
+		HeartRate: uint16(toUint32(args[2])),
+	}
+}
+
+func parseHRTSTART(message *Message, args []string) {
+	//[3G*1234567890*000B*hrtstart,78]
+	if len(args) < 1 || toUint32(args[0]) == 0 {
+		return
+	}
+
+	message.Health = &Health{
+		Time:      message.ReceiveTime,
+		HeartRate: uint16(toUint32(args[0])),
+	}
+}
+
+func parseOXYGEN(message *Message, args []string) {
+	//[3G*1234567890*0009*oxygen,98]
+	if len(args) < 1 {
+		return
+	}
+
+	message.Health = &Health{
+		Time: message.ReceiveTime,
+		SpO2: uint8(toUint32(args[0])),
+	}
+}
+
+func parseBTEMP2(message *Message, args []string) {
+	//[3G*1234567890*000D*btemp2,1,36.6]
+	if len(args) < 2 {
+		return
+	}
+
+	temperature, err := toFloat(args[1])
+	if err != nil {
+		return
+	}
+
+	message.Health = &Health{
+		Time:        message.ReceiveTime,
+		Temperature: temperature,
+	}
+}
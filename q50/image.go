@@ -0,0 +1,50 @@
+package q50
+
+import (
+	"bytes"
+	"time"
+)
+
+const (
+	IMG      = "img"
+	RCAPTURE = "rcapture"
+)
+
+// imageTimeLayout is the capture time of an img frame, yyMMddHHmmss.
+const imageTimeLayout = "060102150405"
+
+// jpegEnd is the end of image marker, the last bytes of a complete JPEG.
+var jpegEnd = []byte{0xFF, 0xD9}
+
+// parseIMG decodes a photo upload. A photo bigger than a frame comes in
+// several img frames with the same capture time.
+func parseIMG(message *Message, payload []byte) error {
+	//[3G*1234567890*LLLL*img,5,181018150101,<jpeg>]
+	fields := bytes.SplitN(payload, []byte{','}, 3)
+	if len(fields) < 3 {
+		return ErrShortFrame
+	}
+
+	captured, err := time.Parse(imageTimeLayout, string(fields[1]))
+	if err == nil {
+		message.DeviceTime = captured
+	}
+
+	image, err := Unescape(fields[2])
+	if err != nil {
+		return err
+	}
+
+	message.Payload = image
+	return nil
+}
+
+// IsImageComplete reports whether data ends with the JPEG end of image marker.
+func IsImageComplete(data []byte) bool {
+	return bytes.HasSuffix(data, jpegEnd)
+}
+
+// Capture makes the watch take a photo and upload it in img frames.
+func Capture() Command {
+	return Command{Name: RCAPTURE}
+}
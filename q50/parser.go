@@ -1,6 +1,7 @@
 package q50
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"strconv"
@@ -8,13 +9,38 @@ import (
 	"time"
 )
 
+// Position sources
+const (
+	SourceGPS  = "gps"
+	SourceLBS  = "lbs"
+	SourceWiFi = "wifi"
+)
+
 const (
 	LK     = "LK"
 	UD     = "UD"
 	UD2    = "UD2"
+	AL     = "AL"
 	CONFIG = "CONFIG"
 )
 
+// OtherType stands for the frame types the protocol doesn't define.
+const OtherType = "other"
+
+// KnownType returns t if it is a frame type of the protocol and OtherType
+// otherwise. Frame types are sent by the client, counting them by name
+// must not grow without bound.
+func KnownType(t string) string {
+	switch t {
+	case LK, UD, UD2, AL, CONFIG, BPHRT, HRTSTART, OXYGEN, BTEMP2, TK, TKQ, TKQ2, IMG:
+		return t
+	}
+	if _, ok := Commands[t]; ok {
+		return t
+	}
+	return OtherType
+}
+
 type Message struct {
 	MessageType    string
 	NetType        string
@@ -24,109 +50,375 @@ type Message struct {
 	DeviceTime     time.Time
 	Latitude       float64
 	Longitude      float64
+	GPSValid       bool
+	Speed          float64
+	Course         float64
+	Altitude       float64
+	Satellites     uint8
+	SignalStrength uint8
+	Steps          uint32
+	Tumbles        uint32
+	Historical     bool
+	Status         Status
+	CellTowers     []CellTower
+	WifiAPs        []WifiAP
+	Source         string
+	Accuracy       float64
+	Config         *DeviceConfig
+	Alarm          string
+	Health         *Health
+	Payload        []byte
+	ClockSkew      time.Duration
+	ClockSkewed    bool
 }
 
+// CellTower is a GSM base station seen by the watch.
+type CellTower struct {
+	MCC    uint16
+	MNC    uint16
+	LAC    uint32
+	CellID uint32
+	RSSI   int32
+}
+
+// WifiAP is a WiFi access point seen by the watch.
+type WifiAP struct {
+	SSID string
+	MAC  string
+	RSSI int32
+}
+
+var (
+	ErrBadHemisphere = errors.New("bad hemisphere")
+	ErrBadCoordinate = errors.New("bad coordinate")
+	ErrBadDate       = errors.New("bad date")
+	ErrBadStatus     = errors.New("bad status word")
+)
+
 func Parse(data *[]byte) (*Message, error) {
 	if len(*data) == 0 {
 		return nil, errors.New("no data")
 	}
 
-	text := strings.Trim(string(*data), " ")
+	buf := bytes.Trim(*data, " ")
 
-	if len(text) < 10 {
+	if len(buf) < 10 {
 		return nil, errors.New("broken message")
 	}
 
-	bktIndex := strings.Index(text, "[")
-	if bktIndex != 0 {
-		return nil, errors.New("expected [")
-	}
+	message := new(Message)
+	for len(buf) > 0 {
+		frame, n, err := ReadFrame(buf)
+		if err != nil {
+			return nil, err
+		}
+		buf = bytes.TrimLeft(buf[n:], " \r\n")
 
-	lastBktIndex := strings.LastIndex(text, "]")
-	if lastBktIndex == -1 {
-		return nil, errors.New("expected last ]")
+		if err := parseFrame(message, frame); err != nil {
+			return nil, err
+		}
 	}
 
-	fieldsBktFunc := func(r rune) bool {
-		return r == '[' || r == ']'
-	}
-	f := strings.FieldsFunc(text, fieldsBktFunc)
+	return message, nil
+}
+
+// FrameError is the error of one frame in a buffer of several.
+type FrameError struct {
+	Index  int
+	Offset int
+	ID     string
+	Type   string
+	Err    error
+}
 
-	fieldsAstFunc := func(r rune) bool {
-		return r == '*' || r == ','
+func (e *FrameError) Error() string {
+	if len(e.ID) == 0 {
+		return fmt.Sprintf("frame %d at offset %d: %v", e.Index, e.Offset, e.Err)
 	}
+	return fmt.Sprintf("frame %d at offset %d, id %s, type %s: %v", e.Index, e.Offset, e.ID, e.Type, e.Err)
+}
 
-	message := new(Message)
-	for _, v := range f {
-		if strings.Trim(v, " ") == "" {
-			continue
-		}
+func (e *FrameError) Unwrap() error {
+	return e.Err
+}
 
-		messageFields := strings.FieldsFunc(v, fieldsAstFunc)
+// ParseAll returns a message for every frame in data. Frames that can't be
+// framed or decoded are skipped and reported as *FrameError, in order.
+func ParseAll(data []byte) ([]*Message, []error) {
+	var messages []*Message
+	var errs []error
 
-		if len(messageFields) < 7 {
-			continue
+	// resync is set after a malformed frame, whose remaining bytes are
+	// skipped without another error
+	resync := false
+	for offset, index := 0, 0; offset < len(data); index++ {
+		frame, start, advance, err := NextFrame(data[offset:], true)
+		if !resync && len(bytes.Trim(data[offset:offset+start], " \r\n")) != 0 {
+			errs = append(errs, &FrameError{Index: index, Offset: offset, Err: errors.New("expected [")})
+			index++
 		}
+		resync = err != nil
 
-		if len(messageFields[1]) == 0 {
+		frameOffset := offset + start
+		offset += advance
+
+		if err != nil {
+			errs = append(errs, &FrameError{Index: index, Offset: frameOffset, Err: err})
 			continue
 		}
+		if frame == nil {
+			break
+		}
 
-		message.NetType = messageFields[0]
-		message.ID = messageFields[1]
-		message.MessageType = messageFields[3]
-		message.ReceiveTime = time.Now()
-
-		switch message.MessageType {
-		case LK:
-			parseLK(message, messageFields)
-		case UD:
-			parseUD(message, messageFields)
-		case UD2:
-			parseUD(message, messageFields)
-		case CONFIG:
-			parseCONFIG(message, messageFields)
+		message := new(Message)
+		if err := parseFrame(message, frame); err != nil {
+			errs = append(errs, &FrameError{Index: index, Offset: frameOffset, ID: frame.ID, Type: frame.Type(), Err: err})
+		} else {
+			messages = append(messages, message)
 		}
 	}
 
+	return messages, errs
+}
+
+// ParseFrame returns the message of one frame.
+func ParseFrame(frame *Frame) (*Message, error) {
+	message := new(Message)
+	if err := parseFrame(message, frame); err != nil {
+		return nil, err
+	}
 	return message, nil
 }
 
-func parseLK(message *Message, messageFields []string) {
+// parseFrame decodes frame into message. Fields of a type the frame
+// doesn't carry keep their values.
+func parseFrame(message *Message, frame *Frame) error {
+	args := frame.Args()
+
+	if frame.Type() == UD2 {
+		// a stored position must not replace a newer one from the same batch
+		point := *message
+		setHeader(&point, frame)
+		if err := parseUD2(&point, args); err != nil {
+			return err
+		}
+		if !point.DeviceTime.Before(message.DeviceTime) {
+			*message = point
+		}
+		return nil
+	}
+
+	setHeader(message, frame)
+
+	switch message.MessageType {
+	case LK:
+		if err := parseLK(message, args); err != nil {
+			return err
+		}
+	case UD:
+		message.Historical = false
+		if err := parseUD(message, args); err != nil {
+			return err
+		}
+	case AL:
+		if err := parseAL(message, args); err != nil {
+			return err
+		}
+	case CONFIG:
+		parseCONFIG(message, args)
+	case BPHRT:
+		parseBPHRT(message, args)
+	case HRTSTART:
+		parseHRTSTART(message, args)
+	case OXYGEN:
+		parseOXYGEN(message, args)
+	case BTEMP2:
+		parseBTEMP2(message, args)
+	case TK:
+		if err := parseTK(message, frame.Payload()); err != nil {
+			return err
+		}
+	case IMG:
+		if err := parseIMG(message, frame.Payload()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func setHeader(message *Message, frame *Frame) {
+	message.NetType = frame.Vendor
+	message.ID = frame.ID
+	message.MessageType = frame.Type()
+	message.ReceiveTime = time.Now()
+}
+
+func parseLK(message *Message, args []string) error {
 	//[3G*1234567890*000D*LK,23227,0,73]
-	percent, err := strconv.ParseInt(messageFields[6], 10, 8)
+	if len(args) == 0 {
+		return nil
+	}
+
+	if len(args) < 3 {
+		return ErrShortFrame
+	}
+
+	message.Steps = toUint32(args[0])
+	message.Tumbles = toUint32(args[1])
+
+	percent, err := strconv.ParseInt(args[2], 10, 8)
 	if err == nil {
 		message.BatteryPercent = uint8(percent)
 	}
+	return nil
 }
 
-func parseUD(message *Message, messageFields []string) {
+func parseUD(message *Message, args []string) error {
 	//[3G*1234567890*00A0*UD,051118,091654,V,00.000000,N,00.0000000,E,0.00,0.0,0.0,0,28,75,23282,0,00000008,4,255,250,1,46612,6762,122,46612,6761,128,46612,1562,117,46612,1561,113,0,36.6]
-	rawDate := messageFields[4]
-	rawTime := messageFields[5]
+	if len(args) < 7 {
+		return ErrShortFrame
+	}
 
-	sb := fmt.Sprintf("20%s-%s-%sT%s:%s:%s.000Z", rawDate[4:], rawDate[2:len(rawDate)-2], rawDate[0:2],
-		rawTime[0:2], rawTime[2:len(rawTime)-2], rawTime[4:])
+	deviceTime, err := parseDeviceTime(args[0], args[1])
+	if err != nil {
+		return err
+	}
+	message.DeviceTime = deviceTime
+
+	message.GPSValid = args[2] == "A"
+	if message.GPSValid {
+		message.Source = SourceGPS
+	}
 
-	message.DeviceTime, _ = time.Parse(time.RFC3339, sb)
+	lat, err := toCoordinate(args[3], args[4], "N", "S")
+	if err != nil {
+		return err
+	}
 
-	if messageFields[8] == "N" {
-		n, _ := toFloat(messageFields[7])
-		message.Latitude = n
+	lon, err := toCoordinate(args[5], args[6], "E", "W")
+	if err != nil {
+		return err
 	}
 
-	if messageFields[10] == "E" {
-		n, _ := toFloat(messageFields[9])
-		message.Longitude = n
+	message.Latitude = lat
+	message.Longitude = lon
+
+	if len(args) < 15 {
+		return nil
 	}
+
+	message.Speed, _ = toFloat(args[7])
+	message.Course, _ = toFloat(args[8])
+	message.Altitude, _ = toFloat(args[9])
+	message.Satellites = uint8(toUint32(args[10]))
+	message.SignalStrength = uint8(toUint32(args[11]))
+	if percent := toUint32(args[12]); percent <= 100 {
+		message.BatteryPercent = uint8(percent)
+	}
+	message.Steps = toUint32(args[13])
+	message.Tumbles = toUint32(args[14])
+
+	if len(args) < 16 {
+		return nil
+	}
+
+	status, err := ParseStatus(args[15])
+	if err != nil {
+		return err
+	}
+	message.Status = status
+
+	message.CellTowers, message.WifiAPs = parseLBS(args[16:])
+	return nil
 }
 
-func parseUD2(message *Message, messageFields []string) {
-	//[3G*1234567890*00CF*UD2,051118,090924,V,00.000000,N,00.0000000,E,0.00,0.0,0.0,0,100,77,23207,0,00000008,7,255,250,1,46612,6762,146,46612,6761,142,46612,6763,122,46612,1571,122,46612,1562,118,46612,1572,118,46612,9884,117,0,36.6]
+// parseLBS decodes the base station and WiFi lists that follow the status
+// word: count,ta,mcc,mnc,(lac,cid,rssi)...,count,(ssid,mac,rssi)...
+// Lists that don't match their declared count are dropped.
+func parseLBS(args []string) ([]CellTower, []WifiAP) {
+	if len(args) == 0 {
+		return nil, nil
+	}
+
+	var towers []CellTower
+	count := int(toUint32(args[0]))
+	pos := 1
+	if count > 0 {
+		if len(args) < pos+3+count*3 {
+			return nil, nil
+		}
+
+		mcc := uint16(toUint32(args[pos+1]))
+		mnc := uint16(toUint32(args[pos+2]))
+		pos += 3
+
+		towers = make([]CellTower, 0, count)
+		for i := 0; i < count; i++ {
+			towers = append(towers, CellTower{
+				MCC:    mcc,
+				MNC:    mnc,
+				LAC:    toUint32(args[pos]),
+				CellID: toUint32(args[pos+1]),
+				RSSI:   toInt32(args[pos+2]),
+			})
+			pos += 3
+		}
+	}
+
+	if len(args) <= pos {
+		return towers, nil
+	}
+
+	count = int(toUint32(args[pos]))
+	pos++
+	if count == 0 || len(args) < pos+count*3 {
+		return towers, nil
+	}
+
+	aps := make([]WifiAP, 0, count)
+	for i := 0; i < count; i++ {
+		aps = append(aps, WifiAP{
+			SSID: args[pos],
+			MAC:  args[pos+1],
+			RSSI: toInt32(args[pos+2]),
+		})
+		pos += 3
+	}
+
+	return towers, aps
+}
+
+// parseAL decodes an alarm. The layout is the same as UD, the reason of
+// the alarm comes from the status word.
+func parseAL(message *Message, args []string) error {
+	//[3G*1234567890*00A0*AL,051118,091654,V,00.000000,N,00.0000000,E,0.00,0.0,0.0,0,28,75,23282,0,00010000,4,255,250,1,46612,6762,122,46612,6761,128,46612,1562,117,46612,1561,113,0,36.6]
+	message.Historical = false
+	if err := parseUD(message, args); err != nil {
+		return err
+	}
+
+	message.Alarm = message.Status.AlarmReason()
+	return nil
 }
 
-func parseCONFIG(message *Message, messageFields []string) {
-	//[3G*1234567890*007E*CONFIG,TY:g36,UL:300,SY:0,CM:0,WT:0,HR:0,TB:1,CS:0,PP:0,AB:1,HH:1,TR:0,MO:0,FL:1,VD:0,DD:0,SD:0,XY:0,WF:0,WX:0,PH:0,RW:0,MT:1,]
+// IsPosition reports whether the message is a position report.
+func (m *Message) IsPosition() bool {
+	return m.MessageType == UD || m.MessageType == UD2 || m.MessageType == AL
+}
+
+// HasPosition reports whether the message is a position report with
+// coordinates. Watches without a fix or located position report 0,0.
+func (m *Message) HasPosition() bool {
+	return m.IsPosition() && (m.Latitude != 0 || m.Longitude != 0)
+}
+
+// parseUD2 decodes a position the watch buffered while it was offline.
+// The layout is the same as UD, but the point is historical.
+func parseUD2(message *Message, args []string) error {
+	//[3G*1234567890*00CF*UD2,051118,090924,V,00.000000,N,00.0000000,E,0.00,0.0,0.0,0,100,77,23207,0,00000008,7,255,250,1,46612,6762,146,46612,6761,142,46612,6763,122,46612,1571,122,46612,1562,118,46612,1572,118,46612,9884,117,0,36.6]
+	message.Historical = true
+	return parseUD(message, args)
 }
 
 func toFloat(v string) (float64, error) {
@@ -141,3 +433,42 @@ func toFloat(v string) (float64, error) {
 	}
 	return n, nil
 }
+
+// toUint32 returns the decimal value of v or 0 if v is not a number.
+func toUint32(v string) uint32 {
+	n, err := strconv.ParseUint(strings.Trim(v, " "), 10, 32)
+	if err != nil {
+		return 0
+	}
+	return uint32(n)
+}
+
+// toInt32 returns the signed decimal value of v or 0 if v is not a number.
+func toInt32(v string) int32 {
+	n, err := strconv.ParseInt(strings.Trim(v, " "), 10, 32)
+	if err != nil {
+		return 0
+	}
+	return int32(n)
+}
+
+// toCoordinate returns the degrees in v, negated for the southern and
+// western hemispheres.
+func toCoordinate(v, hemisphere, positive, negative string) (float64, error) {
+	n, err := toFloat(v)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %q", ErrBadCoordinate, v)
+	}
+
+	switch hemisphere {
+	case positive:
+		return n, nil
+	case negative:
+		return -n, nil
+	}
+	return 0, fmt.Errorf("%w: %q", ErrBadHemisphere, hemisphere)
+}
+
+func splitArgs(v string) []string {
+	return strings.Split(v, ",")
+}
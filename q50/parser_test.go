@@ -0,0 +1,363 @@
+package q50
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+type frameRule struct {
+	TestName string
+	Message  string
+	Type     string
+	Length   int
+	Consumed int
+	err      error
+}
+
+var frameTests = []frameRule{
+	{
+		TestName: "LK frame",
+		Message:  "[3G*1234567890*000D*LK,23227,0,73]",
+		Type:     LK,
+		Length:   13,
+		Consumed: 34,
+	},
+	{
+		TestName: "Bracket inside payload",
+		Message:  "[3G*1234567890*0006*TK,a]b][3G*1234567890*0002*LK]",
+		Type:     "TK",
+		Length:   6,
+		Consumed: 27,
+	},
+	{
+		TestName: "Truncated header",
+		Message:  "[3G*12345",
+		err:      ErrTruncated,
+	},
+	{
+		TestName: "Truncated content",
+		Message:  "[3G*1234567890*000D*LK,232",
+		err:      ErrTruncated,
+	},
+	{
+		TestName: "Bad hex length",
+		Message:  "[3G*1234567890*00ZD*LK,23227,0,73]",
+		err:      ErrBadLength,
+	},
+	{
+		TestName: "Length mismatch",
+		Message:  "[3G*1234567890*000C*LK,23227,0,73]",
+		err:      ErrLengthMismatch,
+	},
+	{
+		TestName: "Empty id",
+		Message:  "[3G**0002*LK]",
+		err:      ErrBadHeader,
+	},
+}
+
+func TestReadFrame(t *testing.T) {
+	for _, test := range frameTests {
+		t.Run(test.TestName, func(t *testing.T) {
+			frame, n, err := ReadFrame([]byte(test.Message))
+			if err != test.err {
+				t.Fatalf("err = %v, want %v", err, test.err)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if frame.Type() != test.Type || frame.Length != test.Length || n != test.Consumed {
+				t.Errorf("got type %s, length %d, consumed %d", frame.Type(), frame.Length, n)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	b := []byte("[3G*1234567890*000D*LK,23227,0,73][3G*1234567890*00A0*UD,051118,091654,V,00.000000,N,00.0000000,E,0.00,0.0,0.0,0,28,75,23282,0,00000008,4,255,250,1,46612,6762,122,46612,6761,128,46612,1562,117,46612,1561,113,0,36.6]")
+	message, err := Parse(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if message.ID != "1234567890" || message.NetType != "3G" || message.MessageType != UD {
+		t.Error("broken message header", message)
+	}
+
+	if message.BatteryPercent != 75 {
+		t.Error("battery percent is not parsed", message.BatteryPercent)
+	}
+
+	b = []byte("[3G*1234567890*00A0*UD,051118,091654]")
+	if _, err := Parse(&b); err != ErrTruncated {
+		t.Error("truncated frame expected", err)
+	}
+}
+
+func TestParseHemisphere(t *testing.T) {
+	b := []byte("[3G*1234567890*0048*UD,051118,091654,A,33.456900,S,70.6483000,W,0.00,0.0,0.0,0,28,75,23282,0]")
+	message, err := Parse(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if message.Latitude != -33.4569 || message.Longitude != -70.6483 || !message.GPSValid {
+		t.Error("southern and western coordinates are not negative", message.Latitude, message.Longitude)
+	}
+
+	b = []byte("[3G*1234567890*0048*UD,051118,091654,A,33.456900,X,70.6483000,W,0.00,0.0,0.0,0,28,75,23282,0]")
+	if _, err := Parse(&b); !errors.Is(err, ErrBadHemisphere) {
+		t.Error("bad hemisphere expected", err)
+	}
+
+	b = []byte("[3G*1234567890*0044*UD,051118,091654,A,22.5x,N,70.6483000,W,0.00,0.0,0.0,0,28,75,23282,0]")
+	if _, err := Parse(&b); !errors.Is(err, ErrBadCoordinate) {
+		t.Error("bad coordinate expected", err)
+	}
+}
+
+func TestParseUDFields(t *testing.T) {
+	b := []byte("[3G*1234567890*00CD*UD2,161018,060356,A,00.312705,N,00.3389767,E,3.00,116.8,12.5,6,88,2,9714,3,00000001,7,1,250,1,46612,1563,142,46612,1562,145,46612,6772,135,46612,1571,135,46612,1572,129,46612,6762,128,46612,8532,122,0,23.7]")
+	message, err := Parse(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if message.Speed != 3 || message.Course != 116.8 || message.Altitude != 12.5 {
+		t.Error("broken movement fields", message.Speed, message.Course, message.Altitude)
+	}
+
+	if message.Satellites != 6 || message.SignalStrength != 88 || message.BatteryPercent != 2 {
+		t.Error("broken fix quality fields", message.Satellites, message.SignalStrength, message.BatteryPercent)
+	}
+
+	if message.Steps != 9714 || message.Tumbles != 3 {
+		t.Error("broken activity fields", message.Steps, message.Tumbles)
+	}
+}
+
+func TestParseUD2(t *testing.T) {
+	b := []byte("[3G*1234567890*0048*UD,161018,061000,A,10.000000,N,20.0000000,E,0.00,0.0,0.0,0,28,75,23282,0][3G*1234567890*0049*UD2,161018,060000,A,11.000000,N,21.0000000,E,0.00,0.0,0.0,0,28,75,23282,0]")
+	message, err := Parse(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if message.Latitude != 10 || message.Longitude != 20 || message.Historical || message.MessageType != UD {
+		t.Error("historical point replaced a newer one", message.MessageType, message.Latitude, message.Longitude)
+	}
+
+	b = []byte("[3G*1234567890*0049*UD2,161018,060000,A,11.000000,N,21.0000000,E,0.00,0.0,0.0,0,28,75,23282,0]")
+	message, err = Parse(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if message.Latitude != 11 || !message.Historical || message.MessageType != UD2 {
+		t.Error("UD2 point is not historical", message.Latitude, message.Historical)
+	}
+}
+
+func TestParseStatus(t *testing.T) {
+	status, err := ParseStatus("00110008")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !status.SOS || !status.WatchRemoved || !status.Worn || status.LowBattery || !status.HasAlarm() {
+		t.Error("broken status flags", status)
+	}
+
+	if _, err := ParseStatus("0000000G"); err == nil {
+		t.Error("bad status word accepted")
+	}
+}
+
+func TestParseLBS(t *testing.T) {
+	b := []byte("[3G*1234567890*00A0*UD,051118,091654,V,00.000000,N,00.0000000,E,0.00,0.0,0.0,0,28,75,23282,0,00000008,4,255,250,1,46612,6762,122,46612,6761,128,46612,1562,117,46612,1561,113,0,36.6]")
+	message, err := Parse(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(message.CellTowers) != 4 || len(message.WifiAPs) != 0 {
+		t.Fatal("broken LBS lists", message.CellTowers, message.WifiAPs)
+	}
+
+	tower := CellTower{MCC: 250, MNC: 1, LAC: 46612, CellID: 6761, RSSI: 128}
+	if message.CellTowers[1] != tower {
+		t.Error("broken cell tower", message.CellTowers[1])
+	}
+
+	towers, aps := parseLBS(splitArgs("1,255,250,1,46612,6762,122,2,home,a4:5e:60:e1:7d:29,-45,office,1c:3b:f3:2e:ac:11,-71,12.0"))
+	if len(towers) != 1 || len(aps) != 2 {
+		t.Fatal("broken LBS lists", towers, aps)
+	}
+
+	ap := WifiAP{SSID: "office", MAC: "1c:3b:f3:2e:ac:11", RSSI: -71}
+	if aps[1] != ap {
+		t.Error("broken wifi access point", aps[1])
+	}
+}
+
+func TestReply(t *testing.T) {
+	replyTests := map[string]string{
+		"[3G*1234567890*000D*LK,23227,0,73]": "[3G*1234567890*0002*LK]",
+		"[SG*8800000015*0002*LK]":            "[SG*8800000015*0002*LK]",
+		"[3G*1234567890*000D*CONFIG,TY:g36]": "[3G*1234567890*0008*CONFIG,1]",
+		"[3G*1234567890*0003*TKQ]":           "[3G*1234567890*0003*TKQ]",
+		"[3G*1234567890*0009*UD,051118]":     "",
+	}
+
+	for message, reply := range replyTests {
+		frame, _, err := ReadFrame([]byte(message))
+		if err != nil {
+			t.Fatal(message, err)
+		}
+
+		if r := string(Reply(frame)); r != reply {
+			t.Errorf("reply to %s is %s, want %s", message, r, reply)
+		}
+	}
+}
+
+func TestParseCONFIG(t *testing.T) {
+	b := []byte("[3G*1234567890*007E*CONFIG,TY:g36,UL:300,SY:0,CM:0,WT:0,HR:0,TB:1,CS:0,PP:0,AB:1,HH:1,TR:0,MO:0,FL:1,VD:0,DD:0,SD:0,XY:0,WF:1,WX:0,PH:0,RW:0,MT:1,]")
+	message, err := Parse(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	config := message.Config
+	if config == nil {
+		t.Fatal("config is not parsed")
+	}
+
+	if config.Model != "g36" || config.UploadInterval != 5*time.Minute || !config.WiFi || config.HeartRate || config.Video {
+		t.Error("broken device config", config)
+	}
+
+	if len(config.Values) != 23 || config.Values["MT"] != "1" {
+		t.Error("broken config values", config.Values)
+	}
+}
+
+func TestParseAL(t *testing.T) {
+	b := []byte("[3G*1234567890*00A0*AL,051118,091654,A,55.700000,N,37.6000000,E,0.00,0.0,0.0,0,28,75,23282,0,00200000,4,255,250,1,46612,6762,122,46612,6761,128,46612,1562,117,46612,1561,113,0,36.6]")
+	message, err := Parse(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if message.Alarm != AlarmFallDown || !message.IsPosition() || message.Latitude != 55.7 {
+		t.Error("broken alarm", message.Alarm, message.Latitude)
+	}
+}
+
+func TestParseHealth(t *testing.T) {
+	healthTests := map[string]Health{
+		"[3G*1234567890*0013*bphrt,120,79,73,,,,]": {Systolic: 120, Diastolic: 79, HeartRate: 73},
+		"[3G*1234567890*000B*hrtstart,78]":         {HeartRate: 78},
+		"[3G*1234567890*0009*oxygen,98]":           {SpO2: 98},
+		"[3G*1234567890*000D*btemp2,1,36.6]":       {Temperature: 36.6},
+	}
+
+	for frame, health := range healthTests {
+		b := []byte(frame)
+		message, err := Parse(&b)
+		if err != nil {
+			t.Fatal(frame, err)
+		}
+
+		if message.Health == nil {
+			t.Fatal("health is not parsed", frame)
+		}
+
+		health.Time = message.ReceiveTime
+		if *message.Health != health {
+			t.Error("broken health measurement", frame, *message.Health)
+		}
+	}
+}
+
+func TestKnownType(t *testing.T) {
+	for _, v := range []string{LK, UD2, BTEMP2, IMG, TKQ, UPLOAD, RCAPTURE} {
+		if KnownType(v) != v {
+			t.Error("known type is other", v)
+		}
+	}
+
+	if KnownType("UD3") != OtherType || KnownType("lk") != OtherType {
+		t.Error("unknown type is counted by name")
+	}
+}
+
+func TestParseAll(t *testing.T) {
+	b := []byte("[3G*1234567890*000D*LK,23227,0,73][3G*1234567890*0005*LK,23]" +
+		"[3G*1234567890*0047*UD,05111,091654,A,33.456900,S,70.6483000,W,0.00,0.0,0.0,0,28,75,23282,0]" +
+		"[3G*1234567890*00ZZ*LK]junk[3G*1234567890*0009*oxygen,98][3G*1234567890*000D*LK,2")
+
+	messages, errs := ParseAll(b)
+	if len(messages) != 2 || messages[0].MessageType != LK || messages[1].MessageType != OXYGEN {
+		t.Error("broken messages", messages)
+	}
+
+	want := []error{ErrShortFrame, ErrBadDate, ErrBadLength, ErrTruncated}
+	if len(errs) != len(want) {
+		t.Fatal("broken frame errors", errs)
+	}
+
+	for i, err := range errs {
+		frameErr, ok := err.(*FrameError)
+		if !ok || !errors.Is(err, want[i]) {
+			t.Errorf("error %d is %v, want %v", i, err, want[i])
+			continue
+		}
+
+		if i < 2 && (frameErr.ID != "1234567890" || frameErr.Index != i+1) {
+			t.Error("broken frame error", frameErr)
+		}
+	}
+}
+
+func TestDeviceTime(t *testing.T) {
+	deviceTime, err := parseDeviceTime("051118", "091654")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if deviceTime.Format(time.RFC3339) != "2018-11-05T09:16:54Z" {
+		t.Error("broken device time", deviceTime)
+	}
+
+	for _, v := range [][2]string{{"321118", "091654"}, {"051318", "091654"}, {"051118", "251654"}, {"0511", "091654"}} {
+		if _, err := parseDeviceTime(v[0], v[1]); err != ErrBadDate {
+			t.Error("bad date accepted", v)
+		}
+	}
+
+	zone, err := Zone("3")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, offset := range []string{"", "x", "-12.5", "15", "NaN", "Inf", "-Inf"} {
+		if _, err := Zone(offset); err == nil {
+			t.Error("bad time zone offset accepted", offset)
+		}
+	}
+
+	local := InLocation(deviceTime, zone)
+	if local.Format(time.RFC3339) != "2018-11-05T09:16:54+03:00" || local.UTC().Hour() != 6 {
+		t.Error("broken local device time", local)
+	}
+
+	message := &Message{MessageType: UD, DeviceTime: local, ReceiveTime: local.Add(10 * time.Minute)}
+	message.CheckClockSkew(DefaultMaxClockSkew)
+	if message.ClockSkew != 10*time.Minute || !message.ClockSkewed {
+		t.Error("clock skew is not detected", message.ClockSkew)
+	}
+}
@@ -0,0 +1,37 @@
+package q50
+
+const (
+	TKQ  = "TKQ"
+	TKQ2 = "TKQ2"
+)
+
+// replies maps uplink message types to the acknowledgement content the
+// watch waits for. Types without an entry are not acknowledged.
+var replies = map[string]string{
+	LK:     LK,
+	AL:     AL,
+	CONFIG: CONFIG + ",1",
+	TKQ:    TKQ,
+	TKQ2:   TKQ2,
+	BPHRT:  BPHRT,
+	OXYGEN: OXYGEN,
+	BTEMP2: BTEMP2,
+	TK:     TK + ",1",
+	IMG:    IMG + ",1",
+}
+
+// Reply returns the acknowledgement frame for frame, or nil if the
+// message type doesn't expect one.
+func Reply(frame *Frame) []byte {
+	content, ok := replies[frame.Type()]
+	if !ok {
+		return nil
+	}
+
+	if frame.Type() == TK && isTKAck(frame.Payload()) {
+		return nil
+	}
+
+	reply := &Frame{Vendor: frame.Vendor, ID: frame.ID, Content: []byte(content)}
+	return reply.Bytes()
+}
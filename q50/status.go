@@ -0,0 +1,100 @@
+package q50
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// Status bits of the terminal status word. The low half holds states,
+// the high half holds alarms.
+const (
+	statusLowBattery      = 1 << 0
+	statusOutOfFence      = 1 << 1
+	statusIntoFence       = 1 << 2
+	statusWorn            = 1 << 3
+	statusSOS             = 1 << 16
+	statusLowBatteryAlarm = 1 << 17
+	statusOutOfFenceAlarm = 1 << 18
+	statusIntoFenceAlarm  = 1 << 19
+	statusWatchRemoved    = 1 << 20
+	statusFallDown        = 1 << 21
+)
+
+// Alarm reasons
+const (
+	AlarmSOS          = "sos"
+	AlarmFallDown     = "fall"
+	AlarmWatchRemoved = "removed"
+	AlarmLowBattery   = "low_battery"
+	AlarmOutOfFence   = "out_of_fence"
+	AlarmIntoFence    = "into_fence"
+	AlarmUnknown      = "unknown"
+)
+
+// Status is the decoded 8 hex digit terminal status word of UD and AL frames.
+type Status struct {
+	Raw             uint32
+	LowBattery      bool
+	OutOfFence      bool
+	IntoFence       bool
+	Worn            bool
+	SOS             bool
+	LowBatteryAlarm bool
+	OutOfFenceAlarm bool
+	IntoFenceAlarm  bool
+	WatchRemoved    bool
+	FallDown        bool
+}
+
+func ParseStatus(v string) (Status, error) {
+	if len(v) != 8 {
+		return Status{}, fmt.Errorf("%w: %q", ErrBadStatus, v)
+	}
+
+	raw, err := strconv.ParseUint(v, 16, 32)
+	if err != nil {
+		return Status{}, fmt.Errorf("%w: %q", ErrBadStatus, v)
+	}
+
+	return NewStatus(uint32(raw)), nil
+}
+
+func NewStatus(raw uint32) Status {
+	return Status{
+		Raw:             raw,
+		LowBattery:      raw&statusLowBattery != 0,
+		OutOfFence:      raw&statusOutOfFence != 0,
+		IntoFence:       raw&statusIntoFence != 0,
+		Worn:            raw&statusWorn != 0,
+		SOS:             raw&statusSOS != 0,
+		LowBatteryAlarm: raw&statusLowBatteryAlarm != 0,
+		OutOfFenceAlarm: raw&statusOutOfFenceAlarm != 0,
+		IntoFenceAlarm:  raw&statusIntoFenceAlarm != 0,
+		WatchRemoved:    raw&statusWatchRemoved != 0,
+		FallDown:        raw&statusFallDown != 0,
+	}
+}
+
+// HasAlarm reports whether any alarm bit is set.
+func (s Status) HasAlarm() bool {
+	return s.Raw>>16 != 0
+}
+
+// AlarmReason returns the most urgent alarm of the status word.
+func (s Status) AlarmReason() string {
+	switch {
+	case s.SOS:
+		return AlarmSOS
+	case s.FallDown:
+		return AlarmFallDown
+	case s.WatchRemoved:
+		return AlarmWatchRemoved
+	case s.LowBatteryAlarm:
+		return AlarmLowBattery
+	case s.OutOfFenceAlarm:
+		return AlarmOutOfFence
+	case s.IntoFenceAlarm:
+		return AlarmIntoFence
+	}
+	return AlarmUnknown
+}
@@ -0,0 +1,61 @@
+package q50
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// deviceTimeLayout is the DDMMYY and HHMMSS fields of UD frames joined.
+const deviceTimeLayout = "020106150405"
+
+// DefaultMaxClockSkew is the difference between the device and the
+// receive time above which a live position is flagged.
+const DefaultMaxClockSkew = 5 * time.Minute
+
+// parseDeviceTime validates and parses the date and time of a frame. The
+// result is in UTC until the device time zone is known, see InLocation.
+func parseDeviceTime(date, clock string) (time.Time, error) {
+	if len(date) != 6 || len(clock) != 6 {
+		return time.Time{}, ErrBadDate
+	}
+
+	t, err := time.Parse(deviceTimeLayout, date+clock)
+	if err != nil {
+		return time.Time{}, ErrBadDate
+	}
+	return t, nil
+}
+
+// InLocation reads the wall clock of a device time in loc. Watches set to
+// local time with the LZ command report their local wall clock.
+func InLocation(t time.Time, loc *time.Location) time.Time {
+	if t.IsZero() {
+		return t
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc)
+}
+
+// Zone returns the fixed time zone of an LZ offset in hours, e.g. 3, -5 or 5.5.
+func Zone(offset string) (*time.Location, error) {
+	hours, err := strconv.ParseFloat(offset, 64)
+	if err != nil || math.IsNaN(hours) || math.IsInf(hours, 0) || hours < -12 || hours > 14 {
+		return nil, fmt.Errorf("bad time zone offset %q", offset)
+	}
+
+	seconds := int(hours * 3600)
+	return time.FixedZone(fmt.Sprintf("UTC%+g", hours), seconds), nil
+}
+
+// CheckClockSkew records how far the device clock is behind the receive
+// time of a live position and flags it when the difference exceeds max.
+// Stored UD2 positions are old by nature and are not checked.
+func (m *Message) CheckClockSkew(max time.Duration) {
+	if !m.IsPosition() || m.Historical || m.DeviceTime.IsZero() {
+		return
+	}
+
+	m.ClockSkew = m.ReceiveTime.Sub(m.DeviceTime)
+	m.ClockSkewed = m.ClockSkew > max || m.ClockSkew < -max
+}
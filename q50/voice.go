@@ -0,0 +1,87 @@
+package q50
+
+import (
+	"bytes"
+	"errors"
+)
+
+const TK = "TK"
+
+// amrHeader starts every AMR file. Some watches send the audio without it.
+var amrHeader = []byte("#!AMR\n")
+
+// parseTK decodes a voice message. The watch also answers a voice message
+// sent to it with TK,1 or TK,0, which carries no audio.
+func parseTK(message *Message, payload []byte) error {
+	//[3G*1234567890*LLLL*TK,#!AMR...]
+	if isTKAck(payload) {
+		return nil
+	}
+
+	audio, err := Unescape(payload)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.HasPrefix(audio, amrHeader) {
+		audio = append(append([]byte{}, amrHeader...), audio...)
+	}
+
+	message.Payload = audio
+	return nil
+}
+
+func isTKAck(payload []byte) bool {
+	return len(payload) <= 1
+}
+
+// IsVoiceAck reports whether frame is the watch answer to a voice message.
+func IsVoiceAck(frame *Frame) bool {
+	return frame.Type() == TK && isTKAck(frame.Payload())
+}
+
+var ErrBadAMR = errors.New("bad AMR audio")
+
+// amrFrameSizes are the AMR-NB speech frame sizes by mode, without the
+// table of contents byte.
+var amrFrameSizes = [16]int{12, 13, 15, 17, 19, 20, 26, 31, 5, 0, 0, 0, 0, 0, 0, 0}
+
+// Voice splits AMR audio into TK commands that fit a frame. Every command
+// carries whole AMR frames behind the AMR header, so the watch can play
+// each part on its own.
+func Voice(audio []byte) ([]Command, error) {
+	if !bytes.HasPrefix(audio, amrHeader) || len(audio) == len(amrHeader) {
+		return nil, ErrBadAMR
+	}
+
+	// room for the type, the comma and the escaped header
+	maxLen := MaxContentLen - len(TK) - 1 - len(Escape(amrHeader))
+
+	var commands []Command
+	chunk := append([]byte{}, amrHeader...)
+	chunkLen := 0
+	for pos := len(amrHeader); pos < len(audio); {
+		size := amrFrameSizes[(audio[pos]>>3)&0x0F] + 1
+		if pos+size > len(audio) {
+			return nil, ErrBadAMR
+		}
+
+		frame := audio[pos : pos+size]
+		frameLen := len(Escape(frame))
+		if chunkLen+frameLen > maxLen {
+			commands = append(commands, voiceCommand(chunk))
+			chunk = append([]byte{}, amrHeader...)
+			chunkLen = 0
+		}
+
+		chunk = append(chunk, frame...)
+		chunkLen += frameLen
+		pos += size
+	}
+
+	return append(commands, voiceCommand(chunk)), nil
+}
+
+func voiceCommand(audio []byte) Command {
+	return Command{Name: TK, Args: []string{string(Escape(audio))}}
+}
@@ -1,8 +1,9 @@
 package main
 
 import (
+	"Q50RT/geo"
 	"Q50RT/q50"
-	"fmt"
+	"io"
 	"log"
 	"net"
 	"sync"
@@ -11,6 +12,22 @@ import (
 	"github.com/avkspog/brts"
 )
 
+// frameStreams feeds the bytes received from every client to a decoder of
+// its own. The transport splits input on ']', which may also occur inside
+// a payload, so a chunk is not always a frame. One goroutine per client
+// handles its frames in the order they were sent.
+type frameStreams struct {
+	mu      sync.Mutex
+	writers map[*brts.Client]*io.PipeWriter
+	serving sync.WaitGroup
+}
+
+var streams = &frameStreams{writers: make(map[*brts.Client]*io.PipeWriter)}
+
+// lastPoints serializes the updates of the cached last points. A cached
+// message is replaced and never modified, so readers may keep it.
+var lastPoints = &sync.Mutex{}
+
 func StartTelemetryServer(serverConfig *ServerConfig, wg *sync.WaitGroup) {
 	defer wg.Done()
 
@@ -28,16 +45,18 @@ func StartTelemetryServer(serverConfig *ServerConfig, wg *sync.WaitGroup) {
 
 	tcpServer.OnNewConnection(func(c *brts.Client) {
 		log.Printf("accepted connection from: %v", c.Conn.RemoteAddr())
+		DeviceConnections.Add(c)
+		streams.open(c)
 	})
 
 	tcpServer.OnMessageReceive(func(c *brts.Client, data *[]byte) {
-		s := fmt.Sprintf("%s", *data)
-		log.Println(s)
-		go process(data)
+		streams.write(c, *data)
 	})
 
 	tcpServer.OnConnectionLost(func(c *brts.Client) {
 		log.Printf("closing connection from %v", c.Conn.RemoteAddr())
+		streams.close(c)
+		DeviceConnections.Remove(c)
 	})
 
 	if err := tcpServer.Start(); err != nil {
@@ -45,47 +64,336 @@ func StartTelemetryServer(serverConfig *ServerConfig, wg *sync.WaitGroup) {
 	}
 }
 
-func process(data *[]byte) {
-	message, err := q50.Parse(data)
-	if err != nil {
-		log.Println(err)
+// serve handles the frames of a client until its stream is closed.
+func serve(c *brts.Client, r *io.PipeReader) {
+	defer r.Close()
+
+	decoder := q50.NewDecoder(r)
+	for {
+		frame, err := decoder.ReadFrame()
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return
+		}
+		if err != nil {
+			log.Printf("dropping malformed frame from %v: %v", c.Conn.RemoteAddr(), err)
+			Stats.FramingError()
+			continue
+		}
+
+		handle(c, frame)
+		process(frame)
+	}
+}
+
+// handle binds the client to the device of the frame, acknowledges the
+// frame and completes or delivers the device commands.
+func handle(c *brts.Client, frame *q50.Frame) {
+	logFrame(frame)
+
+	DeviceConnections.Bind(c, frame.ID, frame.Vendor)
+	DeviceConnections.Seen(c, frame.Type(), frame.Size())
+	Stats.Frame(frame.Type(), frame.Size())
+	respond(c, frame)
+
+	// a voice message from the watch is not an answer to the one sent to it
+	if frame.Type() != q50.TK || q50.IsVoiceAck(frame) {
+		if cmd, ok := DeviceCommands.Acknowledge(frame.ID, frame.Type(), string(frame.Content)); ok {
+			log.Printf("%s: command %s acknowledged", frame.ID, frame.Type())
+			if cmd.Name == q50.LZ {
+				setZone(cmd)
+			}
+		}
+	}
+
+	if frame.Type() == q50.LK {
+		deliverCommands(frame.ID)
+	}
+}
+
+// logFrame logs a received frame. Voice and photo data is replaced by its size.
+func logFrame(frame *q50.Frame) {
+	switch frame.Type() {
+	case q50.TK, q50.IMG:
+		log.Printf("[%s*%s*%04X*%s,<%d bytes>]", frame.Vendor, frame.ID, frame.Length, frame.Type(),
+			len(frame.Payload()))
+	default:
+		log.Printf("%s", frame.Bytes())
+	}
+}
+
+// respond writes the acknowledgement the watch expects for a frame.
+// Without it the watch reconnects and resends.
+func respond(c *brts.Client, frame *q50.Frame) {
+	reply := q50.Reply(frame)
+	if reply == nil {
 		return
 	}
 
-	if message == nil {
-		log.Println("message is nil")
+	if err := DeviceConnections.Write(c, reply); err != nil {
+		log.Printf("error replying to %v: %v", c.Conn.RemoteAddr(), err)
+	}
+}
+
+// setZone remembers the time zone an acknowledged LZ command set on the watch.
+func setZone(cmd Command) {
+	if len(cmd.Args) != 2 {
 		return
 	}
 
+	if err := DeviceZones.Set(cmd.DeviceID, cmd.Args[1]); err != nil {
+		log.Printf("%s: error setting time zone: %v", cmd.DeviceID, err)
+	}
+}
+
+// deliverCommands sends the queued commands of an online device.
+func deliverCommands(id string) {
+	vendor := DeviceConnections.Vendor(id)
+	DeviceCommands.Flush(id, func(cmd *Command) error {
+		frame, err := q50.Encode(vendor, id, cmd.Frame())
+		if err != nil {
+			log.Printf("%s: error encoding command %s: %v", id, cmd.Name, err)
+			return err
+		}
+
+		if err := DeviceConnections.Send(id, frame); err != nil {
+			log.Printf("%s: error sending command %s: %v", id, cmd.Name, err)
+			return err
+		}
+		return nil
+	})
+}
+
+func process(frame *q50.Frame) {
+	started := time.Now()
+	defer func() {
+		processingSeconds.Observe(time.Since(started).Seconds())
+	}()
+
+	message, err := q50.ParseFrame(frame)
+	if err != nil {
+		log.Printf("%s: %s frame: %v", frame.ID, frame.Type(), err)
+		Stats.ParseError()
+		return
+	}
+
+	accept(message)
+}
+
+// accept stores a decoded message and updates the last point of the device.
+func accept(message *q50.Message) {
 	if len(message.ID) == 0 {
 		log.Println("message id is empty")
 		return
 	}
 
-	cmsg, ok := LocalCache.Get(message.ID)
+	message.DeviceTime = q50.InLocation(message.DeviceTime, DeviceZones.Get(message.ID))
+	message.CheckClockSkew(serverConfig.MaxClockSkew)
+	if message.ClockSkew != 0 {
+		Stats.ClockSkew(message.ClockSkew, message.ClockSkewed)
+	}
+	if message.ClockSkewed {
+		log.Printf("%s: device clock is off by %v", message.ID, message.ClockSkew)
+	}
+
+	locate(message)
+
+	if message.HasPosition() {
+		if err := PositionHistory.Add(message); err != nil {
+			log.Printf("%s: error saving position: %v", message.ID, err)
+		}
+	}
+
+	if message.MessageType == q50.TK && message.Payload != nil {
+		file, err := VoiceStore.Save(message.ID, message.ReceiveTime, message.Payload)
+		if err != nil {
+			log.Printf("%s: error saving voice message: %v", message.ID, err)
+		} else {
+			log.Printf("%s: voice message %s saved, %d bytes", message.ID, file.Name, file.Size)
+		}
+	}
+
+	if message.MessageType == q50.IMG {
+		saveImage(message)
+	}
+
+	if message.Health != nil {
+		DeviceHealth.Add(message.ID, *message.Health)
+	}
+
+	if len(message.Alarm) != 0 {
+		alarm := DeviceAlarms.Add(message)
+		log.Printf("%s: alarm %d %s at lat = %v, lon = %v", message.ID, alarm.ID, alarm.Reason,
+			alarm.Latitude, alarm.Longitude)
+	}
+
+	// subscribers are told about accepted positions, not about heartbeats
+	// or uploads that left the last point as it was
+	updated := message.IsPosition()
+
+	lastPoints.Lock()
+	point := message
+	if cmsg, ok := LocalCache.Get(message.ID); ok {
+		// API calls and subscribers may still read the cached message
+		cached := *cmsg.(*q50.Message)
+		cachedMessage := &cached
+		// an older offline upload leaves the last point as it is
+		if isOutdated(message, cachedMessage) {
+			updated = false
+		} else {
+			cachedMessage.MessageType = message.MessageType
+			cachedMessage.NetType = message.NetType
+			cachedMessage.ReceiveTime = message.ReceiveTime
+			if message.Config != nil {
+				cachedMessage.Config = message.Config
+			}
+			if message.BatteryPercent != 0 {
+				cachedMessage.BatteryPercent = message.BatteryPercent
+			}
+			if message.Steps != 0 {
+				cachedMessage.Steps = message.Steps
+				cachedMessage.Tumbles = message.Tumbles
+			}
+			if message.IsPosition() {
+				if message.HasPosition() {
+					cachedMessage.Latitude = message.Latitude
+					cachedMessage.Longitude = message.Longitude
+				}
+				cachedMessage.DeviceTime = message.DeviceTime
+				cachedMessage.Historical = message.Historical
+				cachedMessage.GPSValid = message.GPSValid
+				cachedMessage.Speed = message.Speed
+				cachedMessage.Course = message.Course
+				cachedMessage.Altitude = message.Altitude
+				cachedMessage.Satellites = message.Satellites
+				cachedMessage.SignalStrength = message.SignalStrength
+				cachedMessage.Status = message.Status
+				cachedMessage.CellTowers = message.CellTowers
+				cachedMessage.WifiAPs = message.WifiAPs
+				cachedMessage.Source = message.Source
+				cachedMessage.Accuracy = message.Accuracy
+				cachedMessage.Alarm = message.Alarm
+				if !message.Historical {
+					cachedMessage.ClockSkew = message.ClockSkew
+					cachedMessage.ClockSkewed = message.ClockSkewed
+				}
+			}
+		}
+		point = cachedMessage
+	}
+	LocalCache.Set(message.ID, point)
+	lastPoints.Unlock()
+
+	if updated {
+		PointUpdates.Publish(*point)
+	}
+}
+
+func (s *frameStreams) open(c *brts.Client) {
+	r, w := io.Pipe()
+	s.mu.Lock()
+	s.writers[c] = w
+	s.mu.Unlock()
+
+	s.serving.Add(1)
+	go func() {
+		defer s.serving.Done()
+		serve(c, r)
+	}()
+}
+
+// write hands data to the decoder of the client. It returns when the
+// decoder has read it, so a client is not read faster than it is served.
+func (s *frameStreams) write(c *brts.Client, data []byte) {
+	s.mu.Lock()
+	w, ok := s.writers[c]
+	s.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	if _, err := w.Write(data); err != nil {
+		log.Printf("error handling data from %v: %v", c.Conn.RemoteAddr(), err)
+	}
+}
+
+func (s *frameStreams) close(c *brts.Client) {
+	s.mu.Lock()
+	w, ok := s.writers[c]
+	delete(s.writers, c)
+	s.mu.Unlock()
+
 	if ok {
-		cachedMessage := cmsg.(*q50.Message)
-		cachedMessage.MessageType = message.MessageType
-		cachedMessage.NetType = message.NetType
-		cachedMessage.ReceiveTime = message.ReceiveTime
-		cachedMessage.DeviceTime = message.DeviceTime
-		if message.BatteryPercent != 0 {
-			cachedMessage.BatteryPercent = message.BatteryPercent
+		_ = w.Close()
+	}
+}
+
+// wait returns when the decoders of all closed clients are done.
+func (s *frameStreams) wait() {
+	s.serving.Wait()
+}
+
+func saveImage(message *q50.Message) {
+	image, ok := DeviceImages.Add(message)
+	if !ok {
+		return
+	}
+
+	captured := message.DeviceTime
+	if captured.IsZero() {
+		captured = message.ReceiveTime
+	}
+
+	file, err := ImageStore.Save(message.ID, captured, image)
+	if err != nil {
+		log.Printf("%s: error saving photo: %v", message.ID, err)
+		return
+	}
+	log.Printf("%s: photo %s saved, %d bytes", message.ID, file.Name, file.Size)
+}
+
+// isOutdated reports whether message is a historical position older than
+// the one already cached. Offline uploads must not move the last point back.
+func isOutdated(message, cached *q50.Message) bool {
+	return message.Historical && message.DeviceTime.Before(cached.DeviceTime)
+}
+
+// locate resolves the position of a message without a GPS fix from the
+// WiFi access points or cell towers it reports. Messages with a fix teach
+// the WiFi database where their access points are.
+func locate(message *q50.Message) {
+	if !message.IsPosition() {
+		return
+	}
+
+	if message.GPSValid {
+		if WifiDB != nil {
+			WifiDB.Learn(message.WifiAPs, message.Latitude, message.Longitude)
 		}
-		if message.Latitude != 0 && message.Longitude != 0 {
-			cachedMessage.Latitude = message.Latitude
-			cachedMessage.Longitude = message.Longitude
+		return
+	}
+
+	if WifiDB != nil && len(message.WifiAPs) != 0 {
+		location, err := WifiDB.Locate(message.WifiAPs)
+		if err == nil {
+			setLocation(message, location, q50.SourceWiFi)
+			return
 		}
-		LocalCache.Set(message.ID, cachedMessage)
-	} else {
-		LocalCache.Set(message.ID, message)
+		log.Printf("%s: %v", message.ID, err)
 	}
 
-	//Debug info
-	log.Println(len(LocalCache.Items))
-	for k, v := range LocalCache.Items {
-		msg := v.Value.(*q50.Message)
-		log.Printf("element %s - %v, id = %v, bat = %v, lat = %v, lon = %v", msg.MessageType,
-			k, msg.ID, msg.BatteryPercent, msg.Latitude, msg.Longitude)
+	if CellDB != nil && len(message.CellTowers) != 0 {
+		location, err := CellDB.Locate(message.CellTowers)
+		if err == nil {
+			setLocation(message, location, q50.SourceLBS)
+			return
+		}
+		log.Printf("%s: %v", message.ID, err)
 	}
 }
+
+func setLocation(message *q50.Message, location geo.Location, source string) {
+	message.Latitude = location.Latitude
+	message.Longitude = location.Longitude
+	message.Accuracy = location.Accuracy
+	message.Source = source
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"Q50RT/q50"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/avkspog/brts"
+)
+
+func setupServer(t *testing.T) func() {
+	dir, err := ioutil.TempDir("", "server")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	Stats = NewStatistics()
+	LocalCache = NewCache()
+	DeviceConnections = NewConnections()
+	DeviceCommands = NewCommandQueue()
+	DeviceAlarms = NewAlarmLog()
+	DeviceHealth = NewHealthLog()
+	DeviceZones = NewZones(time.UTC)
+	DeviceImages = NewImageAssembler()
+	PointUpdates = NewPointBroker()
+	PositionHistory = NewHistory(dir)
+	return func() {
+		_ = os.RemoveAll(dir)
+	}
+}
+
+// udFrame returns a UD frame of the position at minute of the device day.
+func udFrame(id string, minute int) []byte {
+	content := fmt.Sprintf("UD,161018,%02d%02d00,A,%d.000000,N,20.0000000,E,0.00,0.0,0.0,0,28,75,23282,0",
+		minute/60, minute%60, minute)
+	return []byte(fmt.Sprintf("[3G*%s*%04X*%s]", id, len(content), content))
+}
+
+func TestServeOrdered(t *testing.T) {
+	defer setupServer(t)()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		conn, peer := net.Pipe()
+		go func() {
+			_, _ = io.Copy(ioutil.Discard, peer)
+		}()
+		client := &brts.Client{Conn: conn}
+		DeviceConnections.Add(client)
+		streams.open(client)
+
+		id := fmt.Sprintf("123456789%d", i)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for minute := 1; minute <= 50; minute++ {
+				// frames split across chunks like the transport does
+				frame := udFrame(id, minute)
+				streams.write(client, frame[:10])
+				streams.write(client, frame[10:])
+			}
+			streams.close(client)
+		}()
+
+		// readers of the last point run while it is updated
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sub := PointUpdates.Subscribe([]string{id})
+			defer PointUpdates.Unsubscribe(sub)
+			for n := 0; n < 50; n++ {
+				if v, ok := LocalCache.Get(id); ok {
+					_ = v.(*q50.Message).Latitude
+				}
+				for _, point := range sub.Take() {
+					_ = point.Latitude
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	streams.wait()
+
+	for i := 0; i < 4; i++ {
+		id := fmt.Sprintf("123456789%d", i)
+		deadline := time.Now().Add(5 * time.Second)
+		for {
+			v, ok := LocalCache.Get(id)
+			if ok && v.(*q50.Message).Latitude == 50 {
+				break
+			}
+			if time.Now().After(deadline) {
+				t.Fatal("last point is not the last frame", id, v)
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+	}
+}
+
+func TestAcceptOutdated(t *testing.T) {
+	defer setupServer(t)()
+
+	sub := PointUpdates.Subscribe(nil)
+	defer PointUpdates.Unsubscribe(sub)
+
+	received := time.Date(2018, 10, 16, 6, 10, 0, 0, time.UTC)
+	accept(&q50.Message{ID: "1234567890", MessageType: q50.UD, NetType: "3G",
+		DeviceTime: received, ReceiveTime: received, Latitude: 10, Longitude: 20})
+	if points := sub.Take(); len(points) != 1 {
+		t.Fatal("position was not published", points)
+	}
+
+	accept(&q50.Message{ID: "1234567890", MessageType: q50.LK, NetType: "3G",
+		ReceiveTime: received.Add(time.Minute)})
+	if points := sub.Take(); len(points) != 0 {
+		t.Error("heartbeat was published", points)
+	}
+
+	accept(&q50.Message{ID: "1234567890", MessageType: q50.UD2, NetType: "3G", Historical: true,
+		DeviceTime: received.Add(-time.Hour), ReceiveTime: received.Add(time.Minute),
+		Latitude: 11, Longitude: 21, BatteryPercent: 50})
+
+	if points := sub.Take(); len(points) != 0 {
+		t.Error("older offline upload was published", points)
+	}
+
+	v, _ := LocalCache.Get("1234567890")
+	point := v.(*q50.Message)
+	if point.MessageType != q50.LK || !point.ReceiveTime.Equal(received.Add(time.Minute)) || point.Latitude != 10 ||
+		point.BatteryPercent != 0 {
+		t.Error("older offline upload changed the last point", point)
+	}
+}
+
+func TestAcceptEquator(t *testing.T) {
+	defer setupServer(t)()
+
+	received := time.Date(2018, 10, 16, 6, 10, 0, 0, time.UTC)
+	accept(&q50.Message{ID: "1234567890", MessageType: q50.UD, DeviceTime: received, ReceiveTime: received,
+		Latitude: 10, Longitude: 20})
+	accept(&q50.Message{ID: "1234567890", MessageType: q50.UD, DeviceTime: received.Add(time.Minute),
+		ReceiveTime: received.Add(time.Minute), Latitude: 0, Longitude: 32.5})
+
+	v, _ := LocalCache.Get("1234567890")
+	if point := v.(*q50.Message); point.Latitude != 0 || point.Longitude != 32.5 {
+		t.Error("position on the equator is not the last point", point.Latitude, point.Longitude)
+	}
+
+	positions, _, err := PositionHistory.Query("1234567890", HistoryCursor{}, time.Time{}, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(positions) != 2 {
+		t.Error("broken history", len(positions))
+	}
+}
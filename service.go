@@ -10,8 +10,10 @@ import (
 	"net"
 	"os"
 	"os/signal"
+	"runtime"
 	"sync"
 	"syscall"
+	"time"
 
 	"google.golang.org/grpc"
 )
@@ -48,13 +50,17 @@ func (s *APIServer) LastPoint(ctx context.Context, idn *pb.Identifier) (*pb.Poin
 		return &pb.Point{}, nil
 	}
 
-	message, ok := msg.(ps.Message)
+	message, ok := msg.(*ps.Message)
 	if !ok {
 		log.Println("message cast error")
 		return &pb.Point{}, nil
 	}
 
-	point := &pb.Point{
+	return s.toPoint(message), nil
+}
+
+func (s *APIServer) toPoint(message *ps.Message) *pb.Point {
+	return &pb.Point{
 		Version:        s.protocolVersion,
 		MessageType:    message.MessageType,
 		NetType:        message.NetType,
@@ -64,20 +70,698 @@ func (s *APIServer) LastPoint(ctx context.Context, idn *pb.Identifier) (*pb.Poin
 		DeviceTime:     message.DeviceTime.UnixNano(),
 		Latitude:       message.Latitude,
 		Longitude:      message.Longitude,
+		GpsValid:       message.GPSValid,
+		Speed:          message.Speed,
+		Course:         message.Course,
+		Altitude:       message.Altitude,
+		Satellites:     uint32(message.Satellites),
+		SignalStrength: uint32(message.SignalStrength),
+		Steps:          message.Steps,
+		Tumbles:        message.Tumbles,
+		Historical:     message.Historical,
+		Status:         toStatus(message.Status),
+		CellTowers:     toCellTowers(message.CellTowers),
+		WifiAPs:        toWifiAPs(message.WifiAPs),
+		Source:         message.Source,
+		Accuracy:       message.Accuracy,
+		Alarm:          message.Alarm,
+		ClockSkew:      int64(message.ClockSkew),
+		ClockSkewed:    message.ClockSkewed,
+	}
+}
+
+func toCellTowers(towers []ps.CellTower) []*pb.CellTower {
+	result := make([]*pb.CellTower, 0, len(towers))
+	for _, t := range towers {
+		result = append(result, &pb.CellTower{
+			Mcc:    uint32(t.MCC),
+			Mnc:    uint32(t.MNC),
+			Lac:    t.LAC,
+			CellId: t.CellID,
+			Rssi:   t.RSSI,
+		})
+	}
+	return result
+}
+
+func toWifiAPs(aps []ps.WifiAP) []*pb.WifiAP {
+	result := make([]*pb.WifiAP, 0, len(aps))
+	for _, ap := range aps {
+		result = append(result, &pb.WifiAP{
+			Ssid: ap.SSID,
+			Mac:  ap.MAC,
+			Rssi: ap.RSSI,
+		})
+	}
+	return result
+}
+
+func toStatus(status ps.Status) *pb.Status {
+	return &pb.Status{
+		Raw:             status.Raw,
+		LowBattery:      status.LowBattery,
+		OutOfFence:      status.OutOfFence,
+		IntoFence:       status.IntoFence,
+		Worn:            status.Worn,
+		Sos:             status.SOS,
+		LowBatteryAlarm: status.LowBatteryAlarm,
+		OutOfFenceAlarm: status.OutOfFenceAlarm,
+		IntoFenceAlarm:  status.IntoFenceAlarm,
+		WatchRemoved:    status.WatchRemoved,
+		FallDown:        status.FallDown,
+	}
+}
+
+func (s *APIServer) SendCommand(ctx context.Context, req *pb.CommandRequest) (*pb.CommandResponse, error) {
+	if req == nil || len(req.DeviceId) == 0 {
+		log.Println("Invalid device id")
+		return &pb.CommandResponse{}, errors.New("Invalid device id")
+	}
+
+	if s.protocolVersion != req.Version {
+		log.Printf("Protocol version %s not support", req.Version)
+		return &pb.CommandResponse{}, fmt.Errorf("Protocol version %s not support", req.Version)
+	}
+
+	name, ok := ps.CommandName(req.Command)
+	if !ok {
+		log.Printf("Command %s not support", req.Command)
+		return &pb.CommandResponse{}, fmt.Errorf("Command %s not support", req.Command)
+	}
+
+	argc := ps.Commands[name]
+	if len(req.Args) != argc {
+		log.Printf("Command %s expects %d arguments", name, argc)
+		return &pb.CommandResponse{}, fmt.Errorf("Command %s expects %d arguments", name, argc)
+	}
+
+	_, err := ps.Encode(DeviceConnections.Vendor(req.DeviceId), req.DeviceId, ps.Command{Name: name, Args: req.Args})
+	if err != nil {
+		log.Printf("Command %s: %v", name, err)
+		return &pb.CommandResponse{}, fmt.Errorf("Command %s: %v", name, err)
+	}
+
+	cmd := DeviceCommands.Push(req.DeviceId, name, req.Args)
+	if DeviceConnections.Online(req.DeviceId) {
+		deliverCommands(req.DeviceId)
+
+		select {
+		case <-cmd.Done():
+		case <-ctx.Done():
+		case <-time.After(commandReplyTimeout):
+		}
+	}
+
+	c, _ := DeviceCommands.Get(cmd.ID)
+	return s.toCommandResponse(c), nil
+}
+
+func (s *APIServer) SendVoice(ctx context.Context, req *pb.VoiceRequest) (*pb.CommandList, error) {
+	if req == nil || len(req.DeviceId) == 0 {
+		log.Println("Invalid device id")
+		return &pb.CommandList{}, errors.New("Invalid device id")
+	}
+
+	if s.protocolVersion != req.Version {
+		log.Printf("Protocol version %s not support", req.Version)
+		return &pb.CommandList{}, fmt.Errorf("Protocol version %s not support", req.Version)
+	}
+
+	parts, err := ps.Voice(req.Data)
+	if err != nil {
+		log.Printf("%s: %v", req.DeviceId, err)
+		return &pb.CommandList{}, err
+	}
+
+	commands := make([]*Command, 0, len(parts))
+	for _, part := range parts {
+		commands = append(commands, DeviceCommands.Push(req.DeviceId, part.Name, part.Args))
+	}
+
+	if DeviceConnections.Online(req.DeviceId) {
+		deliverCommands(req.DeviceId)
+
+		timeout := time.After(commandReplyTimeout)
+	wait:
+		for _, cmd := range commands {
+			select {
+			case <-cmd.Done():
+			case <-ctx.Done():
+				break wait
+			case <-timeout:
+				break wait
+			}
+		}
+	}
+
+	list := &pb.CommandList{
+		Version:  s.protocolVersion,
+		Commands: make([]*pb.CommandResponse, 0, len(commands)),
+	}
+	for _, cmd := range commands {
+		c, _ := DeviceCommands.Get(cmd.ID)
+		list.Commands = append(list.Commands, s.toCommandResponse(c))
+	}
+	return list, nil
+}
+
+func (s *APIServer) CommandStatus(ctx context.Context, idn *pb.CommandIdentifier) (*pb.CommandResponse, error) {
+	if idn == nil {
+		log.Println("Empty command identifier")
+		return &pb.CommandResponse{}, errors.New("Empty command identifier")
+	}
+
+	if s.protocolVersion != idn.Version {
+		log.Printf("Protocol version %s not support", idn.Version)
+		return &pb.CommandResponse{}, fmt.Errorf("Protocol version %s not support", idn.Version)
+	}
+
+	cmd, ok := DeviceCommands.Get(idn.CommandId)
+	if !ok {
+		log.Printf("command %d not found", idn.CommandId)
+		return &pb.CommandResponse{}, fmt.Errorf("Command %d not found", idn.CommandId)
+	}
+
+	return s.toCommandResponse(cmd), nil
+}
+
+func (s *APIServer) toCommandResponse(cmd Command) *pb.CommandResponse {
+	return &pb.CommandResponse{
+		Version:    s.protocolVersion,
+		CommandId:  cmd.ID,
+		DeviceId:   cmd.DeviceID,
+		Command:    cmd.Description(),
+		Status:     cmd.Status,
+		Reply:      cmd.Reply,
+		CreateTime: cmd.Created.UnixNano(),
+		UpdateTime: cmd.Updated.UnixNano(),
+	}
+}
+
+func (s *APIServer) DeviceInfo(ctx context.Context, idn *pb.Identifier) (*pb.DeviceConfig, error) {
+	if idn == nil {
+		log.Println("Empty client identifier")
+		return &pb.DeviceConfig{}, errors.New("Empty client identifier")
+	}
+
+	if len(idn.ClientId) == 0 {
+		log.Println("Invalid client id")
+		return &pb.DeviceConfig{}, errors.New("Invalid client id")
+	}
+
+	if s.protocolVersion != idn.Version {
+		log.Printf("Protocol version %s not support", idn.Version)
+		return &pb.DeviceConfig{}, fmt.Errorf("Protocol version %s not support", idn.Version)
+	}
+
+	msg, ok := LocalCache.Get(idn.ClientId)
+	if !ok || msg == nil {
+		log.Printf("%s not contains in cache", idn.ClientId)
+		return &pb.DeviceConfig{}, nil
+	}
+
+	message, ok := msg.(*ps.Message)
+	if !ok || message.Config == nil {
+		log.Printf("%s config not received", idn.ClientId)
+		return &pb.DeviceConfig{}, nil
+	}
+
+	config := message.Config
+	return &pb.DeviceConfig{
+		Version:        s.protocolVersion,
+		DeviceId:       message.ID,
+		Model:          config.Model,
+		UploadInterval: int64(config.UploadInterval / time.Second),
+		HeartRate:      config.HeartRate,
+		Wifi:           config.WiFi,
+		Video:          config.Video,
+		Photo:          config.Photo,
+		Values:         config.Values,
+	}, nil
+}
+
+func (s *APIServer) Alarms(ctx context.Context, req *pb.AlarmRequest) (*pb.AlarmList, error) {
+	if req == nil || len(req.DeviceId) == 0 {
+		log.Println("Invalid device id")
+		return &pb.AlarmList{}, errors.New("Invalid device id")
+	}
+
+	if s.protocolVersion != req.Version {
+		log.Printf("Protocol version %s not support", req.Version)
+		return &pb.AlarmList{}, fmt.Errorf("Protocol version %s not support", req.Version)
+	}
+
+	alarms := DeviceAlarms.List(req.DeviceId, req.Unacknowledged)
+	list := &pb.AlarmList{
+		Version: s.protocolVersion,
+		Alarms:  make([]*pb.Alarm, 0, len(alarms)),
+	}
+	for _, alarm := range alarms {
+		list.Alarms = append(list.Alarms, toAlarm(alarm))
+	}
+	return list, nil
+}
+
+func (s *APIServer) AcknowledgeAlarm(ctx context.Context, idn *pb.AlarmIdentifier) (*pb.Alarm, error) {
+	if idn == nil {
+		log.Println("Empty alarm identifier")
+		return &pb.Alarm{}, errors.New("Empty alarm identifier")
+	}
+
+	if s.protocolVersion != idn.Version {
+		log.Printf("Protocol version %s not support", idn.Version)
+		return &pb.Alarm{}, fmt.Errorf("Protocol version %s not support", idn.Version)
+	}
+
+	alarm, ok := DeviceAlarms.Acknowledge(idn.AlarmId)
+	if !ok {
+		log.Printf("alarm %d not found", idn.AlarmId)
+		return &pb.Alarm{}, fmt.Errorf("Alarm %d not found", idn.AlarmId)
+	}
+
+	return toAlarm(alarm), nil
+}
+
+func toAlarm(alarm Alarm) *pb.Alarm {
+	return &pb.Alarm{
+		AlarmId:      alarm.ID,
+		DeviceId:     alarm.DeviceID,
+		Reason:       alarm.Reason,
+		DeviceTime:   alarm.DeviceTime.UnixNano(),
+		ReceiveTime:  alarm.ReceiveTime.UnixNano(),
+		Latitude:     alarm.Latitude,
+		Longitude:    alarm.Longitude,
+		Acknowledged: alarm.Acknowledged,
+	}
+}
+
+func (s *APIServer) HealthHistory(ctx context.Context, req *pb.HealthRequest) (*pb.HealthList, error) {
+	if req == nil || len(req.DeviceId) == 0 {
+		log.Println("Invalid device id")
+		return &pb.HealthList{}, errors.New("Invalid device id")
+	}
+
+	if s.protocolVersion != req.Version {
+		log.Printf("Protocol version %s not support", req.Version)
+		return &pb.HealthList{}, fmt.Errorf("Protocol version %s not support", req.Version)
+	}
+
+	measurements := DeviceHealth.History(req.DeviceId, fromUnixNano(req.From), fromUnixNano(req.To))
+	list := &pb.HealthList{
+		Version:      s.protocolVersion,
+		DeviceId:     req.DeviceId,
+		Measurements: make([]*pb.Health, 0, len(measurements)),
+	}
+	for _, h := range measurements {
+		list.Measurements = append(list.Measurements, &pb.Health{
+			Time:        h.Time.UnixNano(),
+			HeartRate:   uint32(h.HeartRate),
+			Systolic:    uint32(h.Systolic),
+			Diastolic:   uint32(h.Diastolic),
+			Spo2:        uint32(h.SpO2),
+			Temperature: h.Temperature,
+		})
+	}
+	return list, nil
+}
+
+const (
+	defaultHistoryLimit = 1000
+	maxHistoryLimit     = 10000
+)
+
+// History returns a page of the stored positions of a device in [from, to],
+// oldest first. The next page is requested with the returned page token.
+func (s *APIServer) History(ctx context.Context, req *pb.HistoryRequest) (*pb.HistoryResponse, error) {
+	if req == nil || len(req.DeviceId) == 0 {
+		log.Println("Invalid device id")
+		return &pb.HistoryResponse{}, errors.New("Invalid device id")
+	}
+
+	if s.protocolVersion != req.Version {
+		log.Printf("Protocol version %s not support", req.Version)
+		return &pb.HistoryResponse{}, fmt.Errorf("Protocol version %s not support", req.Version)
+	}
+
+	cursor := HistoryCursor{From: fromUnixNano(req.From)}
+	if len(req.PageToken) != 0 {
+		var from int64
+		if _, err := fmt.Sscanf(req.PageToken, "%d.%d", &from, &cursor.Skip); err != nil || cursor.Skip < 0 {
+			log.Printf("Invalid page token %s", req.PageToken)
+			return &pb.HistoryResponse{}, fmt.Errorf("Invalid page token %s", req.PageToken)
+		}
+		cursor.From = time.Unix(0, from)
+	}
+
+	limit := int(req.Limit)
+	if limit == 0 {
+		limit = defaultHistoryLimit
+	}
+	if limit > maxHistoryLimit {
+		limit = maxHistoryLimit
+	}
+
+	positions, next, err := PositionHistory.Query(req.DeviceId, cursor, fromUnixNano(req.To), limit)
+	if err != nil {
+		log.Printf("%s: error reading history: %v", req.DeviceId, err)
+		return &pb.HistoryResponse{}, err
+	}
+
+	response := &pb.HistoryResponse{
+		Version:  s.protocolVersion,
+		DeviceId: req.DeviceId,
+		Points:   make([]*pb.Point, 0, len(positions)),
+	}
+	for _, message := range positions {
+		response.Points = append(response.Points, s.toPoint(message))
+	}
+	if next != nil {
+		response.NextPageToken = fmt.Sprintf("%d.%d", next.From.UnixNano(), next.Skip)
+	}
+	return response, nil
+}
+
+// fromUnixNano converts an API timestamp, 0 is the zero time.
+func fromUnixNano(ns int64) time.Time {
+	if ns == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, ns)
+}
+
+// SetTimeZone sets the time zone the device keeps its clock in, as an
+// offset in hours. An empty offset falls back to the default zone. The
+// watch itself isn't changed, send an LZ command for that.
+func (s *APIServer) SetTimeZone(ctx context.Context, req *pb.TimeZone) (*pb.TimeZone, error) {
+	if req == nil || len(req.DeviceId) == 0 {
+		log.Println("Invalid device id")
+		return &pb.TimeZone{}, errors.New("Invalid device id")
+	}
+
+	if s.protocolVersion != req.Version {
+		log.Printf("Protocol version %s not support", req.Version)
+		return &pb.TimeZone{}, fmt.Errorf("Protocol version %s not support", req.Version)
+	}
+
+	if err := DeviceZones.Set(req.DeviceId, req.Offset); err != nil {
+		log.Printf("%s: error setting time zone: %v", req.DeviceId, err)
+		return &pb.TimeZone{}, err
+	}
+
+	return s.timeZone(req.DeviceId), nil
+}
+
+func (s *APIServer) DeviceTimeZone(ctx context.Context, idn *pb.Identifier) (*pb.TimeZone, error) {
+	if idn == nil {
+		log.Println("Empty client identifier")
+		return &pb.TimeZone{}, errors.New("Empty client identifier")
+	}
+
+	if len(idn.ClientId) == 0 {
+		log.Println("Invalid client id")
+		return &pb.TimeZone{}, errors.New("Invalid client id")
+	}
+
+	if s.protocolVersion != idn.Version {
+		log.Printf("Protocol version %s not support", idn.Version)
+		return &pb.TimeZone{}, fmt.Errorf("Protocol version %s not support", idn.Version)
+	}
+
+	return s.timeZone(idn.ClientId), nil
+}
+
+// timeZone returns the configured offset of the device, empty if it uses
+// the default zone.
+func (s *APIServer) timeZone(deviceID string) *pb.TimeZone {
+	offset, _ := DeviceZones.Offset(deviceID)
+	return &pb.TimeZone{
+		Version:  s.protocolVersion,
+		DeviceId: deviceID,
+		Offset:   offset,
+	}
+}
+
+func (s *APIServer) ListVoice(ctx context.Context, idn *pb.Identifier) (*pb.MediaList, error) {
+	return s.listMedia(VoiceStore, idn)
+}
+
+func (s *APIServer) GetVoice(ctx context.Context, idn *pb.MediaIdentifier) (*pb.Media, error) {
+	return s.getMedia(VoiceStore, idn)
+}
+
+func (s *APIServer) CapturePhoto(ctx context.Context, idn *pb.Identifier) (*pb.CommandResponse, error) {
+	if idn == nil || len(idn.ClientId) == 0 {
+		log.Println("Invalid client id")
+		return &pb.CommandResponse{}, errors.New("Invalid client id")
+	}
+
+	return s.SendCommand(ctx, &pb.CommandRequest{
+		Version:  idn.Version,
+		DeviceId: idn.ClientId,
+		Command:  ps.RCAPTURE,
+	})
+}
+
+func (s *APIServer) ListImages(ctx context.Context, idn *pb.Identifier) (*pb.MediaList, error) {
+	return s.listMedia(ImageStore, idn)
+}
+
+func (s *APIServer) GetImage(ctx context.Context, idn *pb.MediaIdentifier) (*pb.Media, error) {
+	return s.getMedia(ImageStore, idn)
+}
+
+func (s *APIServer) listMedia(store *MediaStore, idn *pb.Identifier) (*pb.MediaList, error) {
+	if idn == nil || len(idn.ClientId) == 0 {
+		log.Println("Invalid client id")
+		return &pb.MediaList{}, errors.New("Invalid client id")
+	}
+
+	if s.protocolVersion != idn.Version {
+		log.Printf("Protocol version %s not support", idn.Version)
+		return &pb.MediaList{}, fmt.Errorf("Protocol version %s not support", idn.Version)
+	}
+
+	files, err := store.List(idn.ClientId)
+	if err != nil {
+		log.Printf("%s: %v", idn.ClientId, err)
+		return &pb.MediaList{}, err
+	}
+
+	list := &pb.MediaList{
+		Version: s.protocolVersion,
+		Files:   make([]*pb.MediaFile, 0, len(files)),
+	}
+	for _, f := range files {
+		list.Files = append(list.Files, toMediaFile(f))
+	}
+	return list, nil
+}
+
+func (s *APIServer) getMedia(store *MediaStore, idn *pb.MediaIdentifier) (*pb.Media, error) {
+	if idn == nil || len(idn.DeviceId) == 0 || len(idn.Name) == 0 {
+		log.Println("Invalid media identifier")
+		return &pb.Media{}, errors.New("Invalid media identifier")
+	}
+
+	if s.protocolVersion != idn.Version {
+		log.Printf("Protocol version %s not support", idn.Version)
+		return &pb.Media{}, fmt.Errorf("Protocol version %s not support", idn.Version)
+	}
+
+	data, err := store.Read(idn.DeviceId, idn.Name)
+	if err != nil {
+		log.Printf("%s: %s: %v", idn.DeviceId, idn.Name, err)
+		return &pb.Media{}, err
+	}
+
+	files, err := store.List(idn.DeviceId)
+	if err != nil {
+		return &pb.Media{}, err
+	}
+
+	media := &pb.Media{Version: s.protocolVersion, Data: data}
+	for _, f := range files {
+		if f.Name == idn.Name {
+			media.File = toMediaFile(f)
+		}
+	}
+	return media, nil
+}
+
+func toMediaFile(f MediaFile) *pb.MediaFile {
+	return &pb.MediaFile{
+		DeviceId: f.DeviceID,
+		Name:     f.Name,
+		Time:     f.Time.UnixNano(),
+		Size:     f.Size,
+	}
+}
+
+func (s *APIServer) ListSessions(ctx context.Context, req *pb.SessionRequest) (*pb.SessionList, error) {
+	if req == nil {
+		log.Println("Empty session request")
+		return &pb.SessionList{}, errors.New("Empty session request")
+	}
+
+	if s.protocolVersion != req.Version {
+		log.Printf("Protocol version %s not support", req.Version)
+		return &pb.SessionList{}, fmt.Errorf("Protocol version %s not support", req.Version)
 	}
 
-	return point, nil
+	sessions := DeviceConnections.Sessions(req.OnlineOnly)
+	list := &pb.SessionList{
+		Version:  s.protocolVersion,
+		Sessions: make([]*pb.Session, 0, len(sessions)),
+	}
+	for _, session := range sessions {
+		list.Sessions = append(list.Sessions, toSession(session))
+	}
+	return list, nil
 }
 
+func (s *APIServer) DeviceOnline(ctx context.Context, idn *pb.Identifier) (*pb.Session, error) {
+	if idn == nil {
+		log.Println("Empty client identifier")
+		return &pb.Session{}, errors.New("Empty client identifier")
+	}
+
+	if len(idn.ClientId) == 0 {
+		log.Println("Invalid client id")
+		return &pb.Session{}, errors.New("Invalid client id")
+	}
+
+	if s.protocolVersion != idn.Version {
+		log.Printf("Protocol version %s not support", idn.Version)
+		return &pb.Session{}, fmt.Errorf("Protocol version %s not support", idn.Version)
+	}
+
+	session, ok := DeviceConnections.Session(idn.ClientId)
+	if !ok {
+		return &pb.Session{DeviceId: idn.ClientId}, nil
+	}
+	return toSession(session), nil
+}
+
+func toSession(session Session) *pb.Session {
+	result := &pb.Session{
+		DeviceId:    session.DeviceID,
+		Online:      session.Online,
+		RemoteAddr:  session.RemoteAddr,
+		ConnectTime: session.Connected.UnixNano(),
+		LastSeen:    session.LastSeen.UnixNano(),
+		Frames:      session.Frames,
+		Bytes:       session.Bytes,
+		Reconnects:  session.Reconnects,
+		Messages:    session.Types,
+	}
+	if !session.Disconnected.IsZero() {
+		result.DisconnectTime = session.Disconnected.UnixNano()
+	}
+	return result
+}
+
+// SubscribePoints streams the points of the requested devices as they are
+// accepted. Points a slow client can't keep up with are coalesced to the
+// latest one per device.
+func (s *APIServer) SubscribePoints(req *pb.SubscribeRequest, stream pb.RoutePoint_SubscribePointsServer) error {
+	if req == nil {
+		log.Println("Empty subscribe request")
+		return errors.New("Empty subscribe request")
+	}
+
+	if s.protocolVersion != req.Version {
+		log.Printf("Protocol version %s not support", req.Version)
+		return fmt.Errorf("Protocol version %s not support", req.Version)
+	}
+
+	sub := PointUpdates.Subscribe(req.DeviceIds)
+	defer func() {
+		PointUpdates.Unsubscribe(sub)
+		log.Printf("point subscription %d closed, %d points coalesced", sub.ID, sub.Coalesced())
+	}()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case <-sub.Ready():
+			for _, point := range sub.Take() {
+				if err := stream.Send(s.toPoint(point)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+// ServerStatistic returns the statistic group named by the command, or all
+// groups for an empty command or "all".
 func (s *APIServer) ServerStatistic(ctx context.Context, command *pb.ServerCommand) (*pb.ServerResponse, error) {
-	return nil, nil
+	if command == nil {
+		log.Println("Empty server command")
+		return &pb.ServerResponse{}, errors.New("Empty server command")
+	}
+
+	if s.protocolVersion != command.Version {
+		log.Printf("Protocol version %s not support", command.Version)
+		return &pb.ServerResponse{}, fmt.Errorf("Protocol version %s not support", command.Version)
+	}
+
+	groups := StatGroups
+	if len(command.Command) != 0 && command.Command != "all" {
+		groups = nil
+		for _, group := range StatGroups {
+			if group == command.Command {
+				groups = []string{group}
+			}
+		}
+		if groups == nil {
+			log.Printf("Statistic %s not support", command.Command)
+			return &pb.ServerResponse{}, fmt.Errorf("Statistic %s not support", command.Command)
+		}
+	}
+
+	response := &pb.ServerResponse{Version: s.protocolVersion}
+	for _, group := range groups {
+		for _, st := range statistics(group) {
+			response.ServerStatistics = append(response.ServerStatistics, &pb.ServerResponse_Statistic{
+				Type:  st.Name,
+				Value: st.Value,
+			})
+		}
+	}
+	return response, nil
+}
+
+// statistics collects a statistic group from the counters and the state of
+// the servers.
+func statistics(group string) []Stat {
+	switch group {
+	case StatConnections:
+		clients, devices := DeviceConnections.Count()
+		return []Stat{
+			stat("connections.clients", uint64(clients)),
+			stat("connections.devices", uint64(devices)),
+			stat("connections.subscribers", uint64(PointUpdates.Len())),
+		}
+	case StatCache:
+		return []Stat{
+			stat("cache.items", uint64(LocalCache.Len())),
+			stat("cache.evicted", LocalCache.Evicted()),
+		}
+	case StatUptime:
+		return append(Stats.Group(group), stat("goroutines", uint64(runtime.NumGoroutine())))
+	}
+	return Stats.Group(group)
 }
 
 func createAPIServer(c *ServerConfig) *APIServer {
 	apiServ := &APIServer{
 		protocolVersion: serverConfig.ProtocolVersion,
 		address:         c.APIAddr(),
-		server:          grpc.NewServer(),
+		server: grpc.NewServer(
+			grpc.ChainUnaryInterceptor(Stats.UnaryInterceptor, unaryMetrics),
+			grpc.ChainStreamInterceptor(Stats.StreamInterceptor, streamMetrics)),
 	}
 
 	pb.RegisterRoutePointServer(apiServ.server, apiServ)
@@ -0,0 +1,192 @@
+package main
+
+import (
+	"Q50RT/q50"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// Statistic groups of the ServerStatistic call
+const (
+	StatConnections = "connections"
+	StatMessages    = "messages"
+	StatErrors      = "errors"
+	StatCache       = "cache"
+	StatUptime      = "uptime"
+	StatAPI         = "api"
+	StatClock       = "clock"
+)
+
+// StatGroups lists the statistic groups in the order they are reported.
+var StatGroups = []string{StatConnections, StatMessages, StatErrors, StatCache, StatUptime, StatAPI, StatClock}
+
+// Statistics counts what the telemetry and API servers handled since start.
+// Frame and error counts are also exported to Prometheus, see metrics.go.
+type Statistics struct {
+	mu            *sync.Mutex
+	started       time.Time
+	frames        map[string]uint64
+	bytes         uint64
+	framingErrors uint64
+	parseErrors   uint64
+	clockChecked  uint64
+	clockSkewed   uint64
+	maxClockSkew  time.Duration
+	calls         map[string]uint64
+	callErrors    map[string]uint64
+}
+
+// Stat is one named value of a statistic group.
+type Stat struct {
+	Name  string
+	Value string
+}
+
+func NewStatistics() *Statistics {
+	return &Statistics{
+		mu:         &sync.Mutex{},
+		started:    time.Now(),
+		frames:     make(map[string]uint64),
+		calls:      make(map[string]uint64),
+		callErrors: make(map[string]uint64),
+	}
+}
+
+// Frame counts a valid frame of the given type. Types the protocol doesn't
+// define are counted together.
+func (s *Statistics) Frame(msgType string, size int) {
+	msgType = q50.KnownType(msgType)
+
+	s.mu.Lock()
+	s.frames[msgType]++
+	s.bytes += uint64(size)
+	s.mu.Unlock()
+	framesReceived.WithLabelValues(msgType).Inc()
+}
+
+// FramingError counts input that couldn't be split into frames.
+func (s *Statistics) FramingError() {
+	s.mu.Lock()
+	s.framingErrors++
+	s.mu.Unlock()
+	frameErrors.WithLabelValues("framing").Inc()
+}
+
+// ParseError counts a frame whose content couldn't be decoded.
+func (s *Statistics) ParseError() {
+	s.mu.Lock()
+	s.parseErrors++
+	s.mu.Unlock()
+	frameErrors.WithLabelValues("parse").Inc()
+}
+
+// ClockSkew counts a live position whose device time was checked against
+// the receive time and whether the device clock was off.
+func (s *Statistics) ClockSkew(skew time.Duration, skewed bool) {
+	if skew < 0 {
+		skew = -skew
+	}
+
+	s.mu.Lock()
+	s.clockChecked++
+	if skewed {
+		s.clockSkewed++
+	}
+	if skew > s.maxClockSkew {
+		s.maxClockSkew = skew
+	}
+	s.mu.Unlock()
+	clockSkewSeconds.Observe(skew.Seconds())
+}
+
+// Call counts an API call and whether it failed.
+func (s *Statistics) Call(method string, err error) {
+	s.mu.Lock()
+	s.calls[method]++
+	if err != nil {
+		s.callErrors[method]++
+	}
+	s.mu.Unlock()
+}
+
+// Uptime returns how long the servers are running.
+func (s *Statistics) Uptime() time.Duration {
+	return time.Since(s.started)
+}
+
+// Group returns the statistics of a group, ordered by name.
+func (s *Statistics) Group(group string) []Stat {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var stats []Stat
+	switch group {
+	case StatMessages:
+		var total uint64
+		for msgType, n := range s.frames {
+			stats = append(stats, stat("messages."+msgType, n))
+			total += n
+		}
+		sortStats(stats)
+		stats = append(stats, stat("messages.total", total), stat("messages.bytes", s.bytes))
+	case StatErrors:
+		stats = append(stats, stat("errors.framing", s.framingErrors), stat("errors.parse", s.parseErrors))
+	case StatClock:
+		stats = append(stats,
+			stat("clock.checked", s.clockChecked),
+			stat("clock.skewed", s.clockSkewed),
+			Stat{Name: "clock.max_skew", Value: s.maxClockSkew.String()})
+	case StatAPI:
+		for method, n := range s.calls {
+			stats = append(stats, stat("api."+method, n))
+		}
+		for method, n := range s.callErrors {
+			stats = append(stats, stat("api.errors."+method, n))
+		}
+		sortStats(stats)
+	case StatUptime:
+		stats = append(stats,
+			Stat{Name: "uptime", Value: s.Uptime().Round(time.Second).String()},
+			Stat{Name: "uptime.seconds", Value: fmt.Sprint(int64(s.Uptime() / time.Second))},
+			Stat{Name: "started", Value: s.started.Format(time.RFC3339)})
+	}
+	return stats
+}
+
+// UnaryInterceptor counts the API calls.
+func (s *Statistics) UnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler) (interface{}, error) {
+	resp, err := handler(ctx, req)
+	s.Call(methodName(info.FullMethod), err)
+	return resp, err
+}
+
+// StreamInterceptor counts the API streams when they end.
+func (s *Statistics) StreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler) error {
+	err := handler(srv, ss)
+	s.Call(methodName(info.FullMethod), err)
+	return err
+}
+
+// methodName returns the method of a full gRPC method name, e.g. LastPoint
+// of /api.routePoint/LastPoint.
+func methodName(fullMethod string) string {
+	return fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+}
+
+func stat(name string, n uint64) Stat {
+	return Stat{Name: name, Value: fmt.Sprint(n)}
+}
+
+func sortStats(stats []Stat) {
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].Name < stats[j].Name
+	})
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestStatistics(t *testing.T) {
+	stats := NewStatistics()
+	stats.Frame("UD", 180)
+	stats.Frame("LK", 24)
+	stats.Frame("UD", 180)
+	stats.Frame("X1", 10)
+	stats.Frame("X2", 10)
+	stats.ParseError()
+	stats.ClockSkew(2*time.Second, false)
+	stats.ClockSkew(-10*time.Minute, true)
+	stats.Call(methodName("/api.routePoint/LastPoint"), nil)
+	stats.Call(methodName("/api.routePoint/LastPoint"), errors.New("Invalid client id"))
+
+	expected := map[string][]Stat{
+		StatMessages: {
+			{"messages.LK", "1"}, {"messages.UD", "2"}, {"messages.other", "2"}, {"messages.total", "5"},
+			{"messages.bytes", "404"},
+		},
+		StatErrors: {{"errors.framing", "0"}, {"errors.parse", "1"}},
+		StatAPI:    {{"api.LastPoint", "2"}, {"api.errors.LastPoint", "1"}},
+		StatClock:  {{"clock.checked", "2"}, {"clock.skewed", "1"}, {"clock.max_skew", "10m0s"}},
+	}
+
+	for group, want := range expected {
+		got := stats.Group(group)
+		if len(got) != len(want) {
+			t.Error("broken statistic group", group, got)
+			continue
+		}
+		for i := range want {
+			if got[i] != want[i] {
+				t.Error("broken statistic", group, got[i], want[i])
+			}
+		}
+	}
+
+	if len(stats.Group(StatUptime)) != 3 {
+		t.Error("broken uptime statistics")
+	}
+}
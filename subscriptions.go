@@ -0,0 +1,125 @@
+package main
+
+import (
+	"Q50RT/q50"
+	"sort"
+	"sync"
+)
+
+// PointBroker pushes accepted points to the API subscribers. Publishing
+// never blocks: a subscriber that is still sending gets only the latest
+// point of every device when it is ready again.
+type PointBroker struct {
+	mu          *sync.RWMutex
+	lastID      uint64
+	subscribers map[uint64]*Subscription
+}
+
+// Subscription is the point feed of one API client.
+type Subscription struct {
+	ID        uint64
+	devices   map[string]bool
+	mu        *sync.Mutex
+	pending   map[string]*q50.Message
+	coalesced uint64
+	ready     chan struct{}
+}
+
+func NewPointBroker() *PointBroker {
+	return &PointBroker{
+		mu:          &sync.RWMutex{},
+		subscribers: make(map[uint64]*Subscription),
+	}
+}
+
+// Subscribe starts a feed of the given devices, or of all devices if none
+// are given.
+func (b *PointBroker) Subscribe(deviceIDs []string) *Subscription {
+	sub := &Subscription{
+		devices: make(map[string]bool, len(deviceIDs)),
+		mu:      &sync.Mutex{},
+		pending: make(map[string]*q50.Message),
+		ready:   make(chan struct{}, 1),
+	}
+	for _, id := range deviceIDs {
+		sub.devices[id] = true
+	}
+
+	b.mu.Lock()
+	b.lastID++
+	sub.ID = b.lastID
+	b.subscribers[sub.ID] = sub
+	b.mu.Unlock()
+	return sub
+}
+
+func (b *PointBroker) Unsubscribe(sub *Subscription) {
+	b.mu.Lock()
+	delete(b.subscribers, sub.ID)
+	b.mu.Unlock()
+}
+
+// Len returns the number of subscriptions.
+func (b *PointBroker) Len() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return len(b.subscribers)
+}
+
+// Publish hands a copy of the point to every subscription of its device.
+func (b *PointBroker) Publish(point q50.Message) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for _, sub := range b.subscribers {
+		if len(sub.devices) != 0 && !sub.devices[point.ID] {
+			continue
+		}
+		sub.push(&point)
+	}
+}
+
+func (sub *Subscription) push(point *q50.Message) {
+	sub.mu.Lock()
+	if _, ok := sub.pending[point.ID]; ok {
+		sub.coalesced++
+	}
+	sub.pending[point.ID] = point
+	sub.mu.Unlock()
+
+	select {
+	case sub.ready <- struct{}{}:
+	default:
+	}
+}
+
+// Ready returns a channel that receives when points are pending.
+func (sub *Subscription) Ready() <-chan struct{} {
+	return sub.ready
+}
+
+// Take returns the pending points in the order they were received.
+func (sub *Subscription) Take() []*q50.Message {
+	sub.mu.Lock()
+	pending := sub.pending
+	sub.pending = make(map[string]*q50.Message)
+	sub.mu.Unlock()
+
+	points := make([]*q50.Message, 0, len(pending))
+	for _, point := range pending {
+		points = append(points, point)
+	}
+
+	sort.Slice(points, func(i, j int) bool {
+		return points[i].ReceiveTime.Before(points[j].ReceiveTime)
+	})
+	return points
+}
+
+// Coalesced returns the number of points replaced by a newer one of the
+// same device before they were sent.
+func (sub *Subscription) Coalesced() uint64 {
+	sub.mu.Lock()
+	defer sub.mu.Unlock()
+	return sub.coalesced
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"Q50RT/q50"
+	"testing"
+	"time"
+)
+
+func TestPointBroker(t *testing.T) {
+	broker := NewPointBroker()
+	all := broker.Subscribe(nil)
+	one := broker.Subscribe([]string{"1234567890"})
+	defer broker.Unsubscribe(one)
+
+	now := time.Now()
+	broker.Publish(q50.Message{ID: "1234567890", ReceiveTime: now, Latitude: 1})
+	broker.Publish(q50.Message{ID: "9876543210", ReceiveTime: now.Add(time.Second)})
+	broker.Publish(q50.Message{ID: "1234567890", ReceiveTime: now.Add(2 * time.Second), Latitude: 2})
+
+	select {
+	case <-all.Ready():
+	default:
+		t.Fatal("subscription is not ready")
+	}
+
+	points := all.Take()
+	if len(points) != 2 || points[0].ID != "9876543210" || points[1].Latitude != 2 {
+		t.Error("broken points", points)
+	}
+
+	if all.Coalesced() != 1 {
+		t.Error("broken coalesced count", all.Coalesced())
+	}
+
+	points = one.Take()
+	if len(points) != 1 || points[0].ID != "1234567890" {
+		t.Error("broken filtered points", points)
+	}
+
+	broker.Unsubscribe(all)
+	broker.Publish(q50.Message{ID: "9876543210", ReceiveTime: now})
+	if len(all.Take()) != 0 || broker.Len() != 1 {
+		t.Error("unsubscribed feed got a point")
+	}
+}
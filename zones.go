@@ -0,0 +1,165 @@
+package main
+
+import (
+	"Q50RT/q50"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+type zone struct {
+	offset string
+	loc    *time.Location
+}
+
+// Zones holds the time zone each watch keeps its clock in. Device times
+// are reported as a wall clock without an offset. A zone is configured
+// through the API or learned from an acknowledged LZ command, and saved
+// to the zones file as CSV lines of id,offset.
+type Zones struct {
+	mu       *sync.RWMutex
+	fallback *time.Location
+	fileName string
+	zones    map[string]zone
+}
+
+func NewZones(fallback *time.Location) *Zones {
+	return &Zones{
+		mu:       &sync.RWMutex{},
+		fallback: fallback,
+		zones:    make(map[string]zone),
+	}
+}
+
+// LoadZones reads the zones saved to fileName. Changes are saved back to
+// it. A missing file is an empty set of zones.
+func LoadZones(fileName string, fallback *time.Location) (*Zones, error) {
+	z := NewZones(fallback)
+	z.fileName = fileName
+
+	f, err := os.Open(fileName)
+	if os.IsNotExist(err) {
+		return z, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	reader := csv.NewReader(f)
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if len(record) != 2 {
+			return nil, fmt.Errorf("zones line %d: expected 2 fields", line)
+		}
+
+		loc, err := q50.Zone(record[1])
+		if err != nil {
+			return nil, fmt.Errorf("zones line %d: %v", line, err)
+		}
+		z.zones[record[0]] = zone{offset: record[1], loc: loc}
+	}
+	return z, nil
+}
+
+// Set configures the zone of the device as an offset in hours, e.g. 3,
+// -5 or 5.5. An empty offset removes it, the device uses the fallback zone.
+func (z *Zones) Set(id, offset string) error {
+	var loc *time.Location
+	if len(offset) != 0 {
+		var err error
+		if loc, err = q50.Zone(offset); err != nil {
+			return err
+		}
+	}
+
+	z.mu.Lock()
+	defer z.mu.Unlock()
+
+	previous, had := z.zones[id]
+	if loc == nil {
+		delete(z.zones, id)
+	} else {
+		z.zones[id] = zone{offset: offset, loc: loc}
+	}
+
+	// a zone that couldn't be saved would be lost on restart
+	if err := z.save(); err != nil {
+		if had {
+			z.zones[id] = previous
+		} else {
+			delete(z.zones, id)
+		}
+		return err
+	}
+	return nil
+}
+
+// Get returns the time zone of the device or the fallback zone if none
+// was set for it.
+func (z *Zones) Get(id string) *time.Location {
+	z.mu.RLock()
+	zn, ok := z.zones[id]
+	z.mu.RUnlock()
+	if !ok {
+		return z.fallback
+	}
+	return zn.loc
+}
+
+// Offset returns the configured offset of the device.
+func (z *Zones) Offset(id string) (string, bool) {
+	z.mu.RLock()
+	defer z.mu.RUnlock()
+	zn, ok := z.zones[id]
+	return zn.offset, ok
+}
+
+func (z *Zones) save() error {
+	if len(z.fileName) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(z.zones))
+	for id := range z.zones {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	tmpName := z.fileName + ".tmp"
+	f, err := os.Create(tmpName)
+	if err != nil {
+		return err
+	}
+
+	writer := csv.NewWriter(f)
+	for _, id := range ids {
+		if err := writer.Write([]string{id, z.zones[id].offset}); err != nil {
+			_ = f.Close()
+			return err
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmpName, z.fileName)
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestZones(t *testing.T) {
+	dir, err := ioutil.TempDir("", "zones")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	fileName := filepath.Join(dir, "zones.csv")
+	zones, err := LoadZones(fileName, time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if zones.Get("1234567890") != time.UTC {
+		t.Error("broken fallback zone")
+	}
+	if err := zones.Set("1234567890", "5.5"); err != nil {
+		t.Fatal(err)
+	}
+	if err := zones.Set("0987654321", "-3"); err != nil {
+		t.Fatal(err)
+	}
+	if err := zones.Set("1234567890", "15"); err == nil {
+		t.Error("invalid offset accepted")
+	}
+	if err := zones.Set("0987654321", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadZones(fileName, time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, offset := time.Date(2020, 1, 1, 0, 0, 0, 0, loaded.Get("1234567890")).Zone(); offset != 5*3600+1800 {
+		t.Error("broken loaded zone", offset)
+	}
+	if offset, ok := loaded.Offset("1234567890"); !ok || offset != "5.5" {
+		t.Error("broken loaded offset", offset)
+	}
+	if _, ok := loaded.Offset("0987654321"); ok {
+		t.Error("cleared zone loaded")
+	}
+}
+
+func TestZonesSaveError(t *testing.T) {
+	dir, err := ioutil.TempDir("", "zones")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	zones, err := LoadZones(filepath.Join(dir, "missing", "zones.csv"), time.UTC)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := zones.Set("1234567890", "3"); err == nil {
+		t.Fatal("unsaved zone reported as set")
+	}
+	if _, ok := zones.Offset("1234567890"); ok || zones.Get("1234567890") != time.UTC {
+		t.Error("unsaved zone is used")
+	}
+}